@@ -2,8 +2,13 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -23,8 +28,16 @@ type Client struct {
 	reader        IOReader
 	writer        io.Writer
 	fatalErr      func(error)
+	state         game.GameState // The state of the game after the latest event, or the one it starts in.
+	saveDir       string         // Directory the save and load commands work in.
 }
 
+// defaultSaveDir is where games are saved unless the client is told otherwise.
+const defaultSaveDir = "saves"
+
+// validSaveName matches the names games can be saved under, keeping saves inside the save directory.
+var validSaveName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 // createClient initializes a new Client instance.
 func createClient(communication game.Protocol, input io.Reader, output io.Writer, errFunc func(error)) *Client {
 	return &Client{
@@ -32,52 +45,230 @@ func createClient(communication game.Protocol, input io.Reader, output io.Writer
 		reader:        bufio.NewReader(input),
 		writer:        output,
 		fatalErr:      errFunc,
+		saveDir:       defaultSaveDir,
 	}
 }
 
 // run starts the main gameplay loop. It alternates between user/auto input
 // and game engine responses, printing outcomes and ending on game over.
-func (c *Client) run() {
+// The game is quit when run returns, so the server never outlives the client.
+func (c *Client) run(ctx context.Context) {
+	defer c.communication.Quit()
+
 	autoPlay := false
 
 	c.printIntro()
 
 	for {
+		move := c.communication.Hit
+
 		// Get user's input.
 		if !autoPlay {
-			command := c.readCommand()
-
-			for !slices.Contains([]string{"hit", "auto"}, command) {
-				c.printCommandError()
-
-				command = c.readCommand()
+			command, ok := c.readCommand()
+			if !ok {
+				return
 			}
 
-			if command == "auto" {
+			fields := strings.Fields(command)
+
+			switch {
+			case command == "quit":
+				fmt.Fprintln(c.writer, "You flee the hive. The bees will be waiting...")
+				return
+			case command == "auto":
 				autoPlay = true
+			case command == "hit":
+			case command == "defend":
+				move = c.communication.Defend
+			case len(fields) == 2 && fields[0] == "hit":
+				target, ok := game.ParseBeeType(fields[1])
+				if !ok {
+					c.printCommandError()
+					continue
+				}
+
+				move = func(ctx context.Context) (game.Event, error) {
+					return c.communication.Aim(ctx, target)
+				}
+			case len(fields) == 2 && fields[0] == "use":
+				item, ok := game.ParseItem(fields[1])
+				if !ok {
+					c.printCommandError()
+					continue
+				}
+
+				move = func(ctx context.Context) (game.Event, error) {
+					return c.communication.Use(ctx, item)
+				}
+			default:
+				if err := c.runCommand(ctx, command); err != nil {
+					c.printInterrupted(err)
+					return
+				}
+
+				continue
 			}
 		}
 
-		event := c.communication.Hit()
+		event, err := move(ctx)
+		if err != nil && !autoPlay && !isProtocolErr(ctx, err) {
+			// The server turned the move down, so the player keeps their turn.
+			fmt.Fprintf(c.writer, "You can't do that: %s\n", err)
+			continue
+		}
 
+		// Drones that hatched at the start of the round are told before the player's move.
+		for err == nil && event.Type == game.DronesHatched {
+			c.state = event.State
+			fmt.Fprintln(c.writer, event.Message)
+
+			event, err = c.communication.WaitForCPU(ctx)
+		}
+
+		if err != nil {
+			c.printInterrupted(err)
+			return
+		}
+
+		c.state = event.State
 		fmt.Fprintln(c.writer, event.Message)
 
-		if event.Type == game.GameFinished {
+		if event.Finished {
 			c.printGameSummary(event.State)
 			return
 		}
 
-		event = c.communication.WaitForCPU()
+		event, err = c.communication.WaitForCPU(ctx)
+		if err != nil {
+			c.printInterrupted(err)
+			return
+		}
 
+		c.state = event.State
 		fmt.Fprintln(c.writer, event.Message)
 
-		if event.Type == game.GameFinished {
+		if event.Finished {
 			c.printGameSummary(event.State)
 			return
 		}
 	}
 }
 
+// runCommand carries out a command that doesn't use up the player's turn. It only
+// returns an error if the game can no longer be played.
+func (c *Client) runCommand(ctx context.Context, command string) error {
+	fields := strings.Fields(command)
+
+	switch {
+	case command == "history":
+		c.printHistory()
+	case command == "inventory":
+		c.printInventory()
+	case len(fields) == 2 && fields[0] == "save":
+		return c.saveGame(ctx, fields[1])
+	case len(fields) == 2 && fields[0] == "load":
+		return c.loadGame(ctx, fields[1])
+	default:
+		c.printCommandError()
+	}
+
+	return nil
+}
+
+// saveGame asks the server for the game so far and writes it to the named save file.
+func (c *Client) saveGame(ctx context.Context, name string) error {
+	path, err := c.savePath(name)
+	if err != nil {
+		fmt.Fprintf(c.writer, "Could not save the game: %s\n", err)
+		return nil
+	}
+
+	save, err := c.communication.Save(ctx)
+	if isProtocolErr(ctx, err) {
+		return err
+	}
+
+	if err == nil {
+		err = save.WriteFile(path)
+	}
+
+	if err != nil {
+		fmt.Fprintf(c.writer, "Could not save the game: %s\n", err)
+		return nil
+	}
+
+	fmt.Fprintf(c.writer, "Game saved to %s.\n", path)
+
+	return nil
+}
+
+// loadGame reads the named save file and resumes the game from it.
+func (c *Client) loadGame(ctx context.Context, name string) error {
+	path, err := c.savePath(name)
+	if err != nil {
+		fmt.Fprintf(c.writer, "Could not load the game: %s\n", err)
+		return nil
+	}
+
+	save, err := game.ReadSavedGame(path)
+	if err != nil {
+		fmt.Fprintf(c.writer, "Could not load the game: %s\n", err)
+		return nil
+	}
+
+	state, err := c.communication.Load(ctx, save)
+	if isProtocolErr(ctx, err) {
+		return err
+	}
+
+	if err != nil {
+		fmt.Fprintf(c.writer, "Could not load the game: %s\n", err)
+		return nil
+	}
+
+	c.state = state
+
+	beesAlive := 0
+	for _, bee := range state.Hive {
+		if bee.Health > 0 {
+			beesAlive++
+		}
+	}
+
+	fmt.Fprintf(c.writer, "Game loaded from %s. Round %d: you have %d HP and %d bees remain.\n", path, state.Round, state.Player.Health, beesAlive)
+
+	return nil
+}
+
+// savePath returns the file a game with the given name is saved to.
+func (c *Client) savePath(name string) (string, error) {
+	if !validSaveName.MatchString(name) {
+		return "", fmt.Errorf("%q is not a valid save name, use only letters, numbers, - and _", name)
+	}
+
+	return filepath.Join(c.saveDir, name+".json"), nil
+}
+
+// isProtocolErr reports whether err means the game can no longer be played, as opposed
+// to a request the server turned down.
+func isProtocolErr(ctx context.Context, err error) bool {
+	return errors.Is(err, game.ErrQuit) || (err != nil && ctx.Err() != nil)
+}
+
+// commandHelp lists every command the player can type, shown in the intro and whenever
+// a command isn't recognized.
+const commandHelp = `Commands:
+> hit       — Attempt a strike on the hive
+> hit type  — Aim at the queen, a worker or a drone
+> defend    — Brace yourself to block part of the next sting
+> use item  — Use an item instead of attacking
+> inventory — List the items you carry
+> auto      — Let fate decide and simulate the entire game
+> history   — Review every action taken so far
+> save name — Save the game to continue it later
+> load name — Resume a saved game
+> quit      — Flee the hive and end the game`
+
 // printIntro displays a welcome message and game instructions to the player.
 func (c *Client) printIntro() {
 	fmt.Fprintln(c.writer, `🐝 Welcome to Bees In The Trap 🐝
@@ -93,35 +284,63 @@ Each turn, you can strike the hive... but beware:
 ⚔️ OBJECTIVE:
 Destroy the hive before it destroys you.
 
-Commands:
-> hit       — Attempt a strike on the hive
-> auto      — Let fate decide and simulate the entire game
+`+commandHelp+`
 
 Let the stinger-slinging begin...`)
 }
 
 // readCommand prompts the player and reads a line of input from the reader.
-// Returns the trimmed command string.
-func (c *Client) readCommand() string {
+// Returns the trimmed command string, or false if no command could be read.
+func (c *Client) readCommand() (string, bool) {
 	fmt.Fprint(c.writer, "> ")
 
 	command, err := c.reader.ReadString('\n')
 	if err != nil {
 		c.fatalErr(err)
+		return "", false
 	}
 
 	command = strings.Replace(command, "\n", "", -1)
 
-	return command
+	return command, true
 }
 
 // printCommandError displays a message for unrecognized commands.
 func (c *Client) printCommandError() {
-	fmt.Fprintln(c.writer, `Invalid Command!
+	fmt.Fprintln(c.writer, "Invalid Command!\n\n"+commandHelp)
+}
 
-Commands:
-> hit       — Attempt a strike on the hive
-> auto      — Let fate decide and simulate the entire game`)
+// printHistory lists every action taken so far in the game.
+func (c *Client) printHistory() {
+	if len(c.state.Journal) == 0 {
+		fmt.Fprintln(c.writer, "Nothing has happened yet. Make your move!")
+		return
+	}
+
+	fmt.Fprintln(c.writer, "📖 History")
+
+	for _, entry := range c.state.Journal {
+		fmt.Fprintln(c.writer, entry)
+	}
+}
+
+// printInventory lists the items the player carries and how to use them.
+func (c *Client) printInventory() {
+	if len(c.state.Player.Inventory) == 0 {
+		fmt.Fprintln(c.writer, "Your pockets are empty.")
+		return
+	}
+
+	fmt.Fprintln(c.writer, "🎒 Inventory")
+
+	for _, item := range slices.Sorted(maps.Keys(c.state.Player.Inventory)) {
+		fmt.Fprintf(c.writer, "%d × %s (use %s) — %s\n", c.state.Player.Inventory[item], item, item.Key(), item.Description())
+	}
+}
+
+// printInterrupted explains why the game ended before it was finished.
+func (c *Client) printInterrupted(err error) {
+	fmt.Fprintf(c.writer, "The game was interrupted: %s\n", err)
 }
 
 // printGameSummary formats and displays the final game state summary.
@@ -131,6 +350,23 @@ func (c *Client) printGameSummary(state game.GameState) {
 	var queensFate string
 	var workerBeesAlive uint
 	var droneBeesAlive uint
+	var collapse string
+	var attacks uint
+	var damageDealt int
+	var damageTaken int
+	var beesKilled uint
+	var defends uint
+	var damageBlocked int
+	var itemsUsed uint
+	var stuns uint
+	var crits uint
+	var critsTaken uint
+	var poisonTaken int
+	var queenFed int
+	var swarms uint
+	var commands uint
+	var hatched uint
+	var finalMoments strings.Builder
 	var finalCommentary string
 
 	if state.Player.Health <= 0 {
@@ -158,6 +394,10 @@ func (c *Client) printGameSummary(state game.GameState) {
 
 	// Count surviving bees.
 	for _, bee := range state.Hive {
+		if bee.Health <= 0 {
+			continue
+		}
+
 		if bee.Type == game.WorkerBee {
 			workerBeesAlive++
 		} else if bee.Type == game.DroneBee {
@@ -165,10 +405,80 @@ func (c *Client) printGameSummary(state game.GameState) {
 		}
 	}
 
+	// Report the bees that died with the queen.
+	if state.Collapsed > 0 {
+		collapse = fmt.Sprintf("%d bees died with the Queen", state.Collapsed)
+	} else {
+		collapse = "The hive never collapsed"
+	}
+
+	// Tally the combat record from the journal.
+	for _, entry := range state.Journal {
+		switch {
+		case entry.Type == game.PlayerDefended:
+			defends++
+		case entry.Type == game.ItemUsed:
+			itemsUsed++
+		case entry.Type == game.PlayerStunned:
+			stuns++
+		case entry.Type == game.BeePoisoned:
+			// Poison on the bees is neither dealt nor taken by the player.
+		case entry.Type == game.QueenFed:
+			queenFed += entry.Outcome.HealthAfter - entry.Outcome.HealthBefore
+		case entry.Type == game.QueenCommanded:
+			commands++
+		case entry.Type == game.DronesHatched:
+			hatched += entry.Outcome.Hatched
+		case entry.ByPlayer:
+			attacks++
+			damageDealt += entry.Outcome.Damage
+
+			if entry.Outcome.Critical {
+				crits++
+			}
+
+			if entry.Type == game.BeeKilled || entry.Type == game.QueenKilled {
+				beesKilled++
+			}
+		case entry.Type != game.HiveCollapsed:
+			damageTaken += entry.Outcome.Damage
+			damageBlocked += entry.Outcome.Blocked
+
+			if entry.Outcome.Critical {
+				critsTaken++
+			}
+
+			if entry.Type == game.PlayerPoisoned {
+				poisonTaken += entry.Outcome.Damage
+			}
+
+			if entry.Outcome.Swarm > 0 {
+				swarms++
+			}
+		}
+	}
+
+	effects := state.Player.Effects.String()
+	if effects == "" {
+		effects = "None"
+	}
+
+	beeEffects := describeBeeEffects(state.Hive)
+
+	// Recount the last few actions of the game.
+	for _, entry := range state.Journal[max(len(state.Journal)-5, 0):] {
+		fmt.Fprintf(&finalMoments, "%s\n", entry)
+	}
+
+	if finalMoments.Len() == 0 {
+		finalMoments.WriteString("Nothing happened.\n")
+	}
+
 	// Final report.
 	fmt.Fprintf(c.writer, `
 📜 Game Summary
 ============================
+Seed          : %d
 Rounds played : %d
 Total hits    : %d
 Total stings  : %d
@@ -177,23 +487,93 @@ Total stings  : %d
 ----------------------------
 Final Health  : %d
 Fate          : %s
+Effects       : %s
 
 🐝 Hive Status
 ----------------------------
 Queen Bee     : %s
 Worker Bees   : %d remaining
 Drone Bees    : %d remaining
-
+Bee effects   : %s
+Collapse      : %s
+Drones hatched: %d
+Queen fed     : %d HP
+Swarms        : %d
+Royal commands: %d
+
+⚔️ Combat Record
+----------------------------
+Hits landed   : %d of %d attacks
+Damage dealt  : %d
+Damage taken  : %d
+Bees killed   : %d
+Critical hits : %d
+Crits taken   : %d
+Turns defended: %d
+Damage blocked: %d
+Items used    : %d
+Turns stunned : %d
+Poison damage : %d
+
+📖 Final Moments
+----------------------------
+%s
 %s
 `,
+		state.Seed,
 		state.Round,
 		state.Hits,
 		state.Stings,
 		state.Player.Health,
 		playersFate,
+		effects,
 		queensFate,
 		workerBeesAlive,
 		droneBeesAlive,
+		beeEffects,
+		collapse,
+		hatched,
+		queenFed,
+		swarms,
+		commands,
+		state.Hits,
+		attacks,
+		damageDealt,
+		damageTaken,
+		beesKilled,
+		crits,
+		critsTaken,
+		defends,
+		damageBlocked,
+		itemsUsed,
+		stuns,
+		poisonTaken,
+		finalMoments.String(),
 		finalCommentary,
 	)
 }
+
+// describeBeeEffects counts the living bees under each status effect, e.g.
+// "2 poisoned, 1 stunned", or returns "None" if no bee is under any.
+func describeBeeEffects(hive []game.Bee) string {
+	var counts []string
+
+	for _, effect := range []game.Effect{game.Poisoned, game.Stunned, game.Enraged, game.Smoked} {
+		count := 0
+		for _, bee := range hive {
+			if bee.Health > 0 && bee.Effects.Has(effect) {
+				count++
+			}
+		}
+
+		if count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count, effect))
+		}
+	}
+
+	if len(counts) == 0 {
+		return "None"
+	}
+
+	return strings.Join(counts, ", ")
+}
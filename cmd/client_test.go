@@ -2,8 +2,11 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -25,20 +28,21 @@ func TestRun(t *testing.T) {
 	output := &bytes.Buffer{}
 	protocol := &MockProtocol{
 		events: []game.Event{
-			{Type: game.PlayerAttack, Message: "You killed a worker bee.", State: game.GameState{}},
-			{Type: game.GameFinished, Message: "You lost!", State: game.GameState{}},
+			{Type: game.BeeKilled, Message: "You killed a worker bee.", State: game.GameState{}},
+			{Type: game.PlayerKilled, Message: "You lost!", Finished: true, State: game.GameState{}},
 		},
 	}
 
 	client := createClient(protocol, input, output, func(err error) {})
 
-	client.run()
+	client.run(context.Background())
 
 	commandErrorStr := `Invalid Command!
 
 Commands:
 > hit       — Attempt a strike on the hive
-> auto      — Let fate decide and simulate the entire game`
+> hit type  — Aim at the queen, a worker or a drone
+> defend    — Brace yourself to block part of the next sting`
 
 	result := output.String()
 
@@ -58,13 +62,13 @@ Commands:
 	output = &bytes.Buffer{}
 	protocol = &MockProtocol{
 		events: []game.Event{
-			{Type: game.GameFinished, Message: "You won!", State: game.GameState{}},
+			{Type: game.QueenKilled, Message: "You won!", Finished: true, State: game.GameState{}},
 		},
 	}
 
 	client = createClient(protocol, input, output, func(err error) {})
 
-	client.run()
+	client.run(context.Background())
 
 	result = output.String()
 
@@ -73,6 +77,341 @@ Commands:
 	}
 }
 
+// TestRunHistory ensures the history command lists the journal without taking a turn.
+func TestRunHistory(t *testing.T) {
+	input := strings.NewReader("history\nhit\nhistory\nquit\n")
+	output := &bytes.Buffer{}
+	protocol := &MockProtocol{
+		events: []game.Event{
+			{Type: game.PlayerMissed, Message: "Miss!", State: game.GameState{Journal: []game.JournalEntry{
+				{Round: 1, Type: game.PlayerMissed, ByPlayer: true, Missed: true, Outcome: game.Outcome{BeeType: game.DroneBee}},
+			}}},
+			{Type: game.BeeMissed, Message: "Buzz!", State: game.GameState{Journal: []game.JournalEntry{
+				{Round: 1, Type: game.PlayerMissed, ByPlayer: true, Missed: true, Outcome: game.Outcome{BeeType: game.DroneBee}},
+				{Round: 1, Type: game.BeeMissed, Missed: true, Outcome: game.Outcome{BeeType: game.WorkerBee}},
+			}}},
+		},
+	}
+
+	client := createClient(protocol, input, output, func(err error) {})
+	client.run(context.Background())
+
+	result := output.String()
+
+	if !strings.Contains(result, "Nothing has happened yet.") {
+		t.Error("Expected history to report an empty journal before the first turn.")
+	}
+
+	if !strings.Contains(result, "Round 1: You → drone bee: missed\nRound 1: worker bee → You: missed") {
+		t.Errorf("Expected history to list the journal. Result:\n\n%s", result)
+	}
+
+	if strings.Contains(result, "Invalid Command!") {
+		t.Error("Expected history to be a valid command.")
+	}
+}
+
+// TestRunAim ensures "hit <type>" aims at that type of bee, unknown types are rejected
+// and an aimed attack the server turns down doesn't use up the turn.
+func TestRunAim(t *testing.T) {
+	input := strings.NewReader("hit queen\nhit wasp\nquit\n")
+	output := &bytes.Buffer{}
+	protocol := &MockProtocol{
+		events: []game.Event{
+			{Type: game.PlayerMissed, Message: "Miss! The Queen bee dodged your aim, better luck next time!"},
+			{Type: game.BeeMissed, Message: "Buzz!"},
+		},
+	}
+
+	client := createClient(protocol, input, output, func(err error) {})
+	client.run(context.Background())
+
+	result := output.String()
+
+	if len(protocol.aims) != 1 || protocol.aims[0] != game.QueenBee || !strings.Contains(result, "dodged your aim") {
+		t.Errorf("Expected the player to aim at the queen. Received: %v", protocol.aims)
+	}
+
+	if !strings.Contains(result, "Invalid Command!") {
+		t.Error("Expected aiming at an unknown bee type to be an invalid command.")
+	}
+
+	input = strings.NewReader("hit drone\nquit\n")
+	output = &bytes.Buffer{}
+	protocol = &MockProtocol{rejectAims: true}
+
+	client = createClient(protocol, input, output, func(err error) {})
+	client.run(context.Background())
+
+	if !strings.Contains(output.String(), "You can't do that: there is no drone bee left to aim at") || !strings.Contains(output.String(), "You flee the hive.") {
+		t.Error("Expected the turned down attack to be reported and the player to keep their turn.")
+	}
+}
+
+// TestRunDefend ensures "defend" plays the player's turn without attacking and that the
+// summary counts the turns defended and the damage blocked.
+func TestRunDefend(t *testing.T) {
+	finalState := game.GameState{
+		Player: game.Player{Health: 0},
+		Journal: []game.JournalEntry{
+			{Round: 1, Type: game.PlayerDefended, ByPlayer: true},
+			{Round: 1, Type: game.PlayerKilled, Outcome: game.Outcome{BeeType: game.QueenBee, Damage: 5, Blocked: 5, HealthBefore: 5, HealthAfter: 0}},
+		},
+	}
+
+	output := &bytes.Buffer{}
+	protocol := &MockProtocol{
+		events: []game.Event{
+			{Type: game.PlayerDefended, Message: "You raise your guard and brace for the next sting."},
+			{Type: game.PlayerKilled, Message: "The Queen bee just killed you!", Finished: true, State: finalState},
+		},
+	}
+
+	client := createClient(protocol, strings.NewReader("defend\n"), output, func(err error) {})
+	client.run(context.Background())
+
+	result := output.String()
+
+	if protocol.defends != 1 || !strings.Contains(result, "You raise your guard") {
+		t.Errorf("Expected the player to defend once. Received: %d", protocol.defends)
+	}
+
+	if !strings.Contains(result, "Hits landed   : 0 of 0 attacks") || !strings.Contains(result, "Turns defended: 1\nDamage blocked: 5") {
+		t.Errorf("Expected the summary to count the defended turn apart from attacks. Received: %s", result)
+	}
+}
+
+// TestRunDronesHatched plays against a real server whose queen lays eggs every round and
+// ensures the drones that hatched are told before the outcome of the player's move.
+func TestRunDronesHatched(t *testing.T) {
+	ctx := context.Background()
+	config := game.DefaultGameConfig()
+	config.SpawnInterval = 1
+	config.SpawnCount = 1
+	config.SpawnCap = 30
+
+	output := &bytes.Buffer{}
+	client := createClient(game.StartupServer(ctx, config, 8, nil), strings.NewReader("defend\nquit\n"), output, func(err error) {})
+	client.run(ctx)
+
+	result := output.String()
+	hatched := strings.Index(result, "The Queen's eggs hatch into a new drone!")
+	defended := strings.Index(result, "You raise your guard and brace for the next sting.")
+
+	if hatched < 0 || defended < hatched {
+		t.Errorf("Expected the hatching to be told before the player's move. Result:\n\n%s", result)
+	}
+
+	if len(client.state.Hive) != 32 {
+		t.Errorf("Expected the client to see the drone that hatched. Hive: %d bees.", len(client.state.Hive))
+	}
+}
+
+// TestRunInventory ensures the inventory is listed without taking a turn, "use <item>"
+// uses the item and the summary counts the items used.
+func TestRunInventory(t *testing.T) {
+	finalState := game.GameState{
+		Player:  game.Player{Health: 0},
+		Journal: []game.JournalEntry{{Round: 1, Type: game.ItemUsed, ByPlayer: true, Outcome: game.Outcome{Item: game.HealingSalve}}},
+	}
+
+	output := &bytes.Buffer{}
+	protocol := &MockProtocol{
+		events: []game.Event{
+			{Type: game.ItemUsed, Message: "You apply a healing salve and recover 25 HP. You have 75 HP left."},
+			{Type: game.PlayerKilled, Message: "The Queen bee just killed you!", Finished: true, State: finalState},
+		},
+	}
+
+	client := createClient(protocol, strings.NewReader("inventory\nuse honey\nuse salve\n"), output, func(err error) {})
+	client.state = game.GameState{Player: game.Player{Inventory: map[game.Item]uint{game.HealingSalve: 2, game.Antihistamine: 1}}}
+	client.run(context.Background())
+
+	result := output.String()
+
+	if !strings.Contains(result, "🎒 Inventory\n2 × healing salve (use salve) — Restores 25 HP\n1 × antihistamine (use antihistamine)") {
+		t.Errorf("Expected the inventory to be listed. Received: %s", result)
+	}
+
+	if !strings.Contains(result, "Invalid Command!") {
+		t.Error("Expected using an unknown item to be an invalid command.")
+	}
+
+	if len(protocol.items) != 1 || protocol.items[0] != game.HealingSalve || !strings.Contains(result, "Items used    : 1") {
+		t.Errorf("Expected the player to use the healing salve. Received: %v", protocol.items)
+	}
+
+	client = createClient(&MockProtocol{}, strings.NewReader("inventory\nquit\n"), output, func(err error) {})
+	output.Reset()
+	client.run(context.Background())
+
+	if !strings.Contains(output.String(), "Your pockets are empty.") {
+		t.Error("Expected an empty inventory to be reported.")
+	}
+}
+
+// TestRunSaveAndLoad plays against a real server, saving the game, taking a turn and
+// loading it back, then checks the failure messages for bad saves.
+func TestRunSaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	input := strings.NewReader("save my-game\nhit\nload my-game\nsave ../escape\nload missing\nquit\n")
+	output := &bytes.Buffer{}
+
+	client := createClient(game.StartupServer(ctx, game.DefaultGameConfig(), 5, nil), input, output, func(err error) {})
+	client.saveDir = t.TempDir()
+	client.run(ctx)
+
+	result := output.String()
+	path := filepath.Join(client.saveDir, "my-game.json")
+
+	expectedStrs := []string{
+		"Game saved to " + path + ".",
+		"Game loaded from " + path + ". Round 1: you have 100 HP and 31 bees remain.",
+		"Could not save the game: \"../escape\" is not a valid save name",
+		"Could not load the game: open " + filepath.Join(client.saveDir, "missing.json"),
+	}
+
+	for _, expectedStr := range expectedStrs {
+		if !strings.Contains(result, expectedStr) {
+			t.Errorf("Expected output to contain \"%s\". Result:\n\n%s", expectedStr, result)
+		}
+	}
+
+	if len(client.state.Journal) != 0 {
+		t.Errorf("Expected loading to rewind the journal to the saved game. Journal: %v", client.state.Journal)
+	}
+
+	// Errors from the server are reported without ending the game.
+	output = &bytes.Buffer{}
+	client = createClient(&MockProtocol{}, strings.NewReader("save my-game\nquit\n"), output, func(err error) {})
+	client.saveDir = t.TempDir()
+	client.run(ctx)
+
+	if !strings.Contains(output.String(), "Could not save the game: simulated save error") || !strings.Contains(output.String(), "You flee the hive.") {
+		t.Errorf("Expected the save error to be reported. Result:\n\n%s", output.String())
+	}
+}
+
+// TestRunQuit ensures that the quit command, a failed read and an interrupted game all
+// stop the client and quit the game so the server is not left waiting.
+func TestRunQuit(t *testing.T) {
+	input := strings.NewReader("quit\n")
+	output := &bytes.Buffer{}
+	protocol := &MockProtocol{}
+
+	client := createClient(protocol, input, output, func(err error) {})
+	client.run(context.Background())
+
+	if !strings.Contains(output.String(), "You flee the hive.") {
+		t.Error("Expected output to contain the farewell message.")
+	}
+
+	if !protocol.quitCalled || protocol.index != 0 {
+		t.Error("Expected the client to quit without taking a turn.")
+	}
+
+	// Running out of input.
+	var readErr error
+
+	protocol = &MockProtocol{}
+	client = &Client{
+		communication: protocol,
+		reader:        &MockReader{},
+		writer:        &bytes.Buffer{},
+		fatalErr: func(err error) {
+			readErr = err
+		},
+	}
+
+	client.run(context.Background())
+
+	if readErr == nil || !protocol.quitCalled {
+		t.Error("Expected a failed read to report the error and quit the game.")
+	}
+
+	// The server going away in the middle of the game.
+	output = &bytes.Buffer{}
+	protocol = &MockProtocol{
+		events: []game.Event{
+			{Type: game.PlayerMissed, Message: "Miss!", State: game.GameState{}},
+		},
+	}
+
+	client = createClient(protocol, strings.NewReader("auto\n"), output, func(err error) {})
+	client.run(context.Background())
+
+	if !strings.Contains(output.String(), "The game was interrupted: "+game.ErrQuit.Error()) {
+		t.Error("Expected output to explain that the game was interrupted.")
+	}
+}
+
+// TestRunKeepsOldEvents plays a full game against a real server while every event the
+// client receives is kept and read from another goroutine. Run it with -race to prove
+// that old events are safe to hold onto while the game carries on.
+func TestRunKeepsOldEvents(t *testing.T) {
+	ctx := context.Background()
+	communication := &keepingProtocol{
+		Protocol: game.StartupServer(ctx, game.DefaultGameConfig(), 21, nil),
+		kept:     make(chan game.Event, 1024),
+	}
+
+	done := make(chan []game.Event)
+	go func() {
+		events := []game.Event{}
+
+		for event := range communication.kept {
+			events = append(events, event)
+
+			for _, old := range events {
+				(&Client{writer: &bytes.Buffer{}}).printGameSummary(old.State)
+			}
+		}
+
+		done <- events
+	}()
+
+	output := &bytes.Buffer{}
+	client := createClient(communication, strings.NewReader("auto\n"), output, func(err error) {})
+	client.run(ctx)
+
+	close(communication.kept)
+	events := <-done
+
+	if len(events) == 0 || !events[len(events)-1].Finished {
+		t.Fatalf("Expected the game to be played to the end. Received %d events.", len(events))
+	}
+
+	for index, event := range events {
+		if len(event.State.Journal) != index+1 {
+			t.Errorf("Expected event %d to keep the %d journal entries it was sent with. Received: %d", index, index+1, len(event.State.Journal))
+		}
+	}
+}
+
+// keepingProtocol passes every event the client receives on to kept.
+type keepingProtocol struct {
+	game.Protocol
+	kept chan game.Event
+}
+
+func (protocol *keepingProtocol) Hit(ctx context.Context) (game.Event, error) {
+	event, err := protocol.Protocol.Hit(ctx)
+	if err == nil {
+		protocol.kept <- event
+	}
+
+	return event, err
+}
+
+func (protocol *keepingProtocol) WaitForCPU(ctx context.Context) (game.Event, error) {
+	event, err := protocol.Protocol.WaitForCPU(ctx)
+	if err == nil {
+		protocol.kept <- event
+	}
+
+	return event, err
+}
+
 // TestReadCommand simulates a failure in the input reader and checks that the fatalErr handler is invoked.
 func TestReadCommand(t *testing.T) {
 	var testErr string
@@ -93,6 +432,23 @@ func TestReadCommand(t *testing.T) {
 	}
 }
 
+// TestPrintHelp ensures the intro and the invalid command help list every command
+// exactly once.
+func TestPrintHelp(t *testing.T) {
+	commands := []string{"> hit       —", "> hit type", "> defend", "> use item", "> inventory", "> auto", "> history", "> save name", "> load name", "> quit"}
+
+	for name, print := range map[string]func(*Client){"intro": (*Client).printIntro, "invalid command help": (*Client).printCommandError} {
+		output := &bytes.Buffer{}
+		print(&Client{writer: output})
+
+		for _, command := range commands {
+			if count := strings.Count(output.String(), command); count != 1 {
+				t.Errorf("Expected the %s to list \"%s\" once. Received: %d", name, command, count)
+			}
+		}
+	}
+}
+
 // TestPrintGameSummary validates that various states produce expected narrative summaries.
 func TestPrintGameSummary(t *testing.T) {
 	scenarios := []struct {
@@ -108,6 +464,49 @@ func TestPrintGameSummary(t *testing.T) {
 		{game.GameState{Hive: []game.Bee{{Type: game.QueenBee, Health: -1, MissChance: 0}}}, "Dead"},
 		{game.GameState{Hive: []game.Bee{{Type: game.WorkerBee, Health: 1, MissChance: 0}}}, "Worker Bees   : 1 remaining"},
 		{game.GameState{Hive: []game.Bee{{Type: game.DroneBee, Health: 1, MissChance: 0}}}, "Drone Bees    : 1 remaining"},
+		{game.GameState{Seed: 1234}, "Seed          : 1234"},
+		{game.GameState{Hive: []game.Bee{{Type: game.WorkerBee, Health: 0, MissChance: 0}}}, "Worker Bees   : 0 remaining"},
+		{game.GameState{Collapsed: 30}, "Collapse      : 30 bees died with the Queen"},
+		{game.GameState{}, "Collapse      : The hive never collapsed"},
+		{game.GameState{}, "Nothing happened."},
+		{game.GameState{Hits: 2, Journal: []game.JournalEntry{
+			{Round: 1, Type: game.BeeHit, ByPlayer: true, Outcome: game.Outcome{BeeType: game.DroneBee, Damage: 30, HealthBefore: 60, HealthAfter: 30}},
+			{Round: 1, Type: game.PlayerStung, Outcome: game.Outcome{BeeType: game.WorkerBee, Damage: 5, HealthBefore: 100, HealthAfter: 95}},
+			{Round: 2, Type: game.PlayerMissed, ByPlayer: true, Missed: true},
+			{Round: 3, Type: game.BeeKilled, ByPlayer: true, Outcome: game.Outcome{BeeType: game.DroneBee, Damage: 30, HealthBefore: 30, HealthAfter: 0}},
+		}}, "Hits landed   : 2 of 3 attacks\nDamage dealt  : 60\nDamage taken  : 5\nBees killed   : 1"},
+		{game.GameState{Journal: []game.JournalEntry{{Round: 9, Type: game.HiveCollapsed}}}, "Round 9: The hive collapsed"},
+		{game.GameState{}, "Effects       : None"},
+		{game.GameState{Journal: []game.JournalEntry{
+			{Round: 1, Type: game.BeeHit, ByPlayer: true, Outcome: game.Outcome{BeeType: game.DroneBee, Damage: 45, HealthBefore: 60, HealthAfter: 15, Critical: true}},
+			{Round: 1, Type: game.PlayerStung, Outcome: game.Outcome{BeeType: game.WorkerBee, Damage: 7, HealthBefore: 100, HealthAfter: 93, Critical: true}},
+			{Round: 2, Type: game.BeeKilled, ByPlayer: true, Outcome: game.Outcome{BeeType: game.DroneBee, Damage: 30, HealthBefore: 15, HealthAfter: 0}},
+		}}, "Bees killed   : 1\nCritical hits : 1\nCrits taken   : 1"},
+		{game.GameState{Player: game.Player{Effects: game.Effects{game.Poisoned: 2}}}, "Effects       : poisoned (2 rounds)"},
+		{game.GameState{}, "Bee effects   : None"},
+		{game.GameState{Hive: []game.Bee{
+			{Type: game.QueenBee, Health: 80, Effects: game.Effects{game.Poisoned: 2}},
+			{Type: game.DroneBee, Health: 30, Effects: game.Effects{game.Stunned: 1, game.Smoked: 2}},
+			{Type: game.DroneBee, Health: 60, Effects: game.Effects{game.Stunned: 1}},
+		}}, "Drone Bees    : 2 remaining\nBee effects   : 1 poisoned, 2 stunned, 1 smoked"},
+		{game.GameState{Journal: []game.JournalEntry{
+			{Round: 1, Type: game.PlayerStunned, ByPlayer: true},
+			{Round: 2, Type: game.PlayerPoisoned, Outcome: game.Outcome{Damage: 5, HealthBefore: 100, HealthAfter: 95}},
+			{Round: 2, Type: game.BeePoisoned, Outcome: game.Outcome{BeeType: game.DroneBee, Damage: 5, HealthBefore: 60, HealthAfter: 55}},
+		}}, "Hits landed   : 0 of 0 attacks\nDamage dealt  : 0\nDamage taken  : 5"},
+		{game.GameState{Journal: []game.JournalEntry{
+			{Round: 1, Type: game.PlayerStunned, ByPlayer: true},
+			{Round: 2, Type: game.PlayerPoisoned, Outcome: game.Outcome{Damage: 5, HealthBefore: 100, HealthAfter: 95}},
+		}}, "Turns stunned : 1\nPoison damage : 5"},
+		{game.GameState{Journal: []game.JournalEntry{
+			{Round: 1, Type: game.QueenFed, Outcome: game.Outcome{BeeType: game.WorkerBee, HealthBefore: 60, HealthAfter: 70}},
+			{Round: 2, Type: game.QueenCommanded, Outcome: game.Outcome{BeeType: game.QueenBee}},
+			{Round: 3, Type: game.BeesSwarmed, Outcome: game.Outcome{BeeType: game.DroneBee, Swarm: 3, SwarmStings: 2, Damage: 4, HealthBefore: 100, HealthAfter: 96}},
+		}}, "Queen fed     : 10 HP\nSwarms        : 1\nRoyal commands: 1"},
+		{game.GameState{Journal: []game.JournalEntry{
+			{Round: 4, Type: game.DronesHatched, Outcome: game.Outcome{BeeType: game.DroneBee, Hatched: 3}},
+			{Round: 8, Type: game.DronesHatched, Outcome: game.Outcome{BeeType: game.DroneBee, Hatched: 2}},
+		}}, "Collapse      : The hive never collapsed\nDrones hatched: 5"},
 	}
 
 	for _, scenario := range scenarios {
@@ -133,31 +532,91 @@ func TestPrintGameSummary(t *testing.T) {
 
 // MockProtocol is used as a testable standin for CommunicationProtocol.
 type MockProtocol struct {
-	events []game.Event
-	index  int
+	events     []game.Event
+	index      int
+	quitCalled bool
+	aims       []game.BeeType // Every bee type the player aimed at.
+	defends    int            // Number of times the player defended.
+	items      []game.Item    // Every item the player used.
+	rejectAims bool           // Whether to turn down every aimed attack.
 }
 
 // Hit is used to simulate CommunicationProtocol's Hit function for testing.
-func (protocol *MockProtocol) Hit() game.Event {
-	event := protocol.events[protocol.index]
-	protocol.index++
+func (protocol *MockProtocol) Hit(ctx context.Context) (game.Event, error) {
+	return protocol.nextEvent()
+}
+
+// Aim records the bee type the player aimed at and returns the next scripted event.
+func (protocol *MockProtocol) Aim(ctx context.Context, target game.BeeType) (game.Event, error) {
+	if protocol.rejectAims {
+		return game.Event{}, fmt.Errorf("there is no %s left to aim at", target)
+	}
+
+	protocol.aims = append(protocol.aims, target)
+
+	return protocol.nextEvent()
+}
 
-	return event
+// Use records the item the player used and returns the next scripted event.
+func (protocol *MockProtocol) Use(ctx context.Context, item game.Item) (game.Event, error) {
+	protocol.items = append(protocol.items, item)
+
+	return protocol.nextEvent()
+}
+
+// Defend records that the player defended and returns the next scripted event.
+func (protocol *MockProtocol) Defend(ctx context.Context) (game.Event, error) {
+	protocol.defends++
+
+	return protocol.nextEvent()
 }
 
 // WaitForCPU is used to simulate CommunicationProtocol's Hit function for testing.
-func (protocol *MockProtocol) WaitForCPU() game.Event {
+func (protocol *MockProtocol) WaitForCPU(ctx context.Context) (game.Event, error) {
+	return protocol.nextEvent()
+}
+
+// Quit records that the client quit the game.
+func (protocol *MockProtocol) Quit() {
+	protocol.quitCalled = true
+}
+
+// nextEvent returns the next scripted event, or game.ErrQuit once the script has run out.
+func (protocol *MockProtocol) nextEvent() (game.Event, error) {
+	if protocol.index >= len(protocol.events) {
+		return game.Event{}, game.ErrQuit
+	}
+
 	event := protocol.events[protocol.index]
 	protocol.index++
 
-	return event
+	return event, nil
+}
+
+// Save refuses to save, as the mock has no game to save.
+func (protocol *MockProtocol) Save(context.Context) (game.SavedGame, error) {
+	return game.SavedGame{}, errors.New("simulated save error")
+}
+
+// Load resumes from the saved state without checking it.
+func (protocol *MockProtocol) Load(ctx context.Context, save game.SavedGame) (game.GameState, error) {
+	return save.State, nil
 }
 
 // Unused methods to satisfy Protocol interface.
-func (protocol *MockProtocol) WaitForPlayer()                              {}
-func (protocol *MockProtocol) HitResponse(string, game.GameState)          {}
-func (protocol *MockProtocol) StingResponse(string, game.GameState)        {}
-func (protocol *MockProtocol) GameFinishedResponse(string, game.GameState) {}
+func (protocol *MockProtocol) WaitForPlayer(context.Context) (game.Request, error) {
+	return game.Request{}, nil
+}
+func (protocol *MockProtocol) HitResponse(context.Context, game.Event) error          { return nil }
+func (protocol *MockProtocol) StingResponse(context.Context, game.Event) error        { return nil }
+func (protocol *MockProtocol) GameFinishedResponse(context.Context, game.Event) error { return nil }
+func (protocol *MockProtocol) SaveResponse(context.Context, game.SavedGame, error) error {
+	return nil
+}
+func (protocol *MockProtocol) LoadResponse(context.Context, game.GameState, error) error {
+	return nil
+}
+func (protocol *MockProtocol) RejectResponse(context.Context, error) error { return nil }
 
 // MockReader simulates a read failure when reading a command.
 type MockReader struct{}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+// runFairness compares the odds the game should be played by at the start of a game with
+// the odds the engine is observed playing by.
+func runFairness(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("fairness", flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	samples := flags.Int("n", 100000, "number of times each side's turn is sampled")
+	seed := flags.Uint64("seed", 0, "seed for the sampled rolls (random when omitted)")
+	rules := flags.String("rules", "", "path to a JSON or TOML ruleset file (standard rules when omitted)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if !isFlagSetIn(flags, "seed") {
+		*seed = rand.Uint64()
+	}
+
+	config, err := loadConfig(*rules)
+	if err != nil {
+		return err
+	}
+
+	report, err := game.CheckFairness(config, game.NewGameState(config, *seed), *samples, *seed)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(output, `⚖️ Fairness Check
+============================
+Samples       : %d of each turn (seed %d)
+`, report.Samples, *seed)
+
+	printOddsTable(output, "🗡️ Player's Attack", report.Expected.Hit, report.Observed.Hit, report.Expected.PlayerMiss, report.Observed.PlayerMiss, report.Attacks)
+	printOddsTable(output, "🐝 Hive's Sting", report.Expected.Sting, report.Observed.Sting, report.Expected.HiveMiss, report.Observed.HiveMiss, report.Stings)
+
+	return nil
+}
+
+// printOddsTable displays the expected and observed odds of every outcome of a turn,
+// along with the verdict of the chi-square test.
+func printOddsTable(output io.Writer, title string, expected, observed map[game.BeeType]float64, expectedMiss, observedMiss float64, test game.ChiSquareTest) {
+	fmt.Fprintf(output, "\n%s\n----------------------------\n%-14s%9s %9s\n", title, "", "expected", "observed")
+
+	for _, beeType := range slices.Sorted(maps.Keys(expected)) {
+		fmt.Fprintf(output, "%-14s%8.2f%% %8.2f%%\n", beeType, expected[beeType]*100, observed[beeType]*100)
+	}
+
+	fmt.Fprintf(output, "%-14s%8.2f%% %8.2f%%\n", "Miss", expectedMiss*100, observedMiss*100)
+
+	verdict := "fair"
+	if test.Deviates {
+		verdict = "DEVIATES from the expected odds"
+	}
+
+	fmt.Fprintf(output, "χ² = %.2f with %d degrees of freedom, p = %.3f: %s\n", test.Statistic, test.DegreesOfFreedom, test.PValue, verdict)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRunFairness ensures the fairness subcommand reports the odds of both sides' turns.
+func TestRunFairness(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	if err := runFairness([]string{"-n", "5000", "-seed", "8"}, output); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, expected := range []string{"Samples       : 5000 of each turn (seed 8)", "🗡️ Player's Attack", "🐝 Hive's Sting", "Queen bee", "Miss", "degrees of freedom"} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("Expected the report to contain %q. Received:\n%s", expected, output.String())
+		}
+	}
+
+	if strings.Contains(output.String(), "DEVIATES") {
+		t.Errorf("Expected the engine to be fair. Received:\n%s", output.String())
+	}
+
+	if err := runFairness([]string{"-n", "0"}, output); err == nil {
+		t.Error("Expected an error without any samples.")
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+const (
+	// defaultTerminalWidth is used when the width of the terminal isn't known.
+	defaultTerminalWidth = 80
+
+	// maxHistogramBins is the most rows a histogram is drawn with.
+	maxHistogramBins = 20
+
+	// minHistogramBar is the narrowest a histogram bar is drawn, however narrow the terminal.
+	minHistogramBar = 10
+)
+
+// histogramStyle holds the characters a histogram is drawn with.
+type histogramStyle struct {
+	bar      string   // Draws a whole character of a bar.
+	partials []string // Draws the fraction of a character left over at the end of a bar, in equal steps.
+	axis     string   // Separates the labels from the bars.
+	dash     string   // Separates the lowest and highest value in a label.
+}
+
+// asciiHistogram draws histograms in plain ASCII, which every terminal and log can show.
+var asciiHistogram = histogramStyle{bar: "#", partials: []string{""}, axis: "|", dash: "-"}
+
+// blockHistogram draws histograms with Unicode block characters, down to eighths of a character.
+var blockHistogram = histogramStyle{
+	bar:      "█",
+	partials: []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"},
+	axis:     "│",
+	dash:     "–",
+}
+
+// histogramBin counts the values that fell between low and high, inclusive.
+type histogramBin struct {
+	low   int
+	high  int
+	count int
+}
+
+// label names the values the bin covers, separating the lowest and highest with dash.
+func (bin histogramBin) label(dash string) string {
+	if bin.low == bin.high {
+		return strconv.Itoa(bin.low)
+	}
+
+	return fmt.Sprintf("%d%s%d", bin.low, dash, bin.high)
+}
+
+// terminalWidth returns the width of the terminal stdout is drawn in. When stdout isn't
+// a terminal it falls back to $COLUMNS, and then to defaultTerminalWidth.
+func terminalWidth() int {
+	if columns, ok := stdoutWidth(); ok {
+		return columns
+	}
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	return defaultTerminalWidth
+}
+
+// createHistogramBins sorts the values into at most maxBins bins of equal size,
+// covering every value from the lowest to the highest.
+func createHistogramBins(values []int, maxBins int) []histogramBin {
+	if len(values) == 0 {
+		return nil
+	}
+
+	low, high := slices.Min(values), slices.Max(values)
+	size := (high - low + maxBins) / maxBins
+
+	bins := make([]histogramBin, (high-low)/size+1)
+	for index := range bins {
+		bins[index].low = low + index*size
+		bins[index].high = bins[index].low + size - 1
+	}
+
+	bins[len(bins)-1].high = high
+
+	for _, value := range values {
+		bins[(value-low)/size].count++
+	}
+
+	return bins
+}
+
+// printHistogram draws a histogram of the values in the given style that fits within
+// width characters.
+func printHistogram(output io.Writer, title string, values []int, width int, style histogramStyle) {
+	fmt.Fprintf(output, "\n%s\n%s\n", title, strings.Repeat("-", 28))
+
+	bins := createHistogramBins(values, maxHistogramBins)
+	if len(bins) == 0 {
+		fmt.Fprintln(output, "Nothing to show.")
+		return
+	}
+
+	labelWidth, countWidth, mostCount := 0, 0, 0
+	for _, bin := range bins {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(bin.label(style.dash)))
+		countWidth = max(countWidth, len(strconv.Itoa(bin.count)))
+		mostCount = max(mostCount, bin.count)
+	}
+
+	// Leave room for the label, the axis, the count and the spaces between them.
+	barWidth := max(width-labelWidth-countWidth-3, minHistogramBar)
+
+	steps := len(style.partials)
+
+	for _, bin := range bins {
+		length := bin.count * barWidth * steps / mostCount
+		if bin.count > 0 && length == 0 {
+			length = 1
+		}
+
+		bar := strings.Repeat(style.bar, length/steps) + style.partials[length%steps]
+		padding := strings.Repeat(" ", barWidth-utf8.RuneCountInString(bar))
+		label := bin.label(style.dash)
+		label = strings.Repeat(" ", labelWidth-utf8.RuneCountInString(label)) + label
+
+		fmt.Fprintf(output, "%s %s%s%s %*d\n", label, style.axis, bar, padding, countWidth, bin.count)
+	}
+}
+
+// printSimulationHistograms draws how the rounds, the player's final health and the
+// stings it took to kill the player were spread across a batch of games, drawn in the
+// given style.
+func printSimulationHistograms(output io.Writer, results []game.GameResult, width int, style histogramStyle) {
+	var rounds, health, stingsToDeath []int
+
+	for _, result := range results {
+		rounds = append(rounds, int(result.Rounds))
+		health = append(health, result.PlayerHealth)
+
+		if !result.Won {
+			stingsToDeath = append(stingsToDeath, int(result.Stings))
+		}
+	}
+
+	printHistogram(output, "⏱️ Rounds to Finish", rounds, width, style)
+	printHistogram(output, "❤️ Final Player Health", health, width, style)
+	printHistogram(output, "💀 Stings to Death", stingsToDeath, width, style)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+// TestCreateHistogramBins ensures values are sorted into bins of equal size covering every value.
+func TestCreateHistogramBins(t *testing.T) {
+	bins := createHistogramBins([]int{1, 2, 2, 5, 9, 10}, 4)
+
+	expected := []histogramBin{{1, 3, 3}, {4, 6, 1}, {7, 9, 1}, {10, 10, 1}}
+	if len(bins) != len(expected) {
+		t.Fatalf("Expected %d bins. Received: %+v", len(expected), bins)
+	}
+
+	for index := range expected {
+		if bins[index] != expected[index] {
+			t.Errorf("Bin %d. Expected: %+v. Received: %+v.", index, expected[index], bins[index])
+		}
+	}
+
+	if bins := createHistogramBins([]int{-3, -1, -3}, 20); len(bins) != 3 || bins[0].count != 2 || bins[0].label("-") != "-3" {
+		t.Errorf("Expected a bin for every value in a narrow range. Received: %+v", bins)
+	}
+
+	if label := (histogramBin{low: 4, high: 9}).label(asciiHistogram.dash); label != "4-9" {
+		t.Errorf("Expected the label to name the range the bin covers. Received: %q", label)
+	}
+
+	if bins := createHistogramBins(nil, 20); bins != nil {
+		t.Errorf("Expected no bins without any values. Received: %+v", bins)
+	}
+}
+
+// TestPrintHistogram ensures histograms fit within the width they are given.
+func TestPrintHistogram(t *testing.T) {
+	values := []int{}
+	for value := range 500 {
+		values = append(values, value%37*value%11)
+	}
+
+	for _, style := range []histogramStyle{asciiHistogram, blockHistogram} {
+		for _, width := range []int{40, 80, 120} {
+			output := &bytes.Buffer{}
+			printHistogram(output, "Title", values, width, style)
+
+			lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+			if lines[0] != "Title" || len(lines) < 10 {
+				t.Fatalf("Unexpected histogram:\n%s", output.String())
+			}
+
+			longest := 0
+			for _, line := range lines[2:] {
+				longest = max(longest, utf8.RuneCountInString(line))
+			}
+
+			if longest != width {
+				t.Errorf("Expected the widest row to be %d characters wide. Received: %d\n%s", width, longest, output.String())
+			}
+		}
+	}
+
+	// Plain ASCII is drawn unless the block characters are asked for.
+	output := &bytes.Buffer{}
+	printHistogram(output, "Title", values, 80, asciiHistogram)
+
+	for _, char := range output.String() {
+		if char > unicode.MaxASCII {
+			t.Fatalf("Expected the histogram to be drawn in ASCII. Received %q in:\n%s", char, output.String())
+		}
+	}
+
+	output = &bytes.Buffer{}
+	printHistogram(output, "Title", nil, 80, asciiHistogram)
+
+	if !strings.Contains(output.String(), "Nothing to show.") {
+		t.Errorf("Expected an empty histogram to say so. Received:\n%s", output.String())
+	}
+}
+
+// TestPrintSimulationHistograms ensures stings to death only count games the player lost.
+func TestPrintSimulationHistograms(t *testing.T) {
+	results := []game.GameResult{
+		{Won: true, Rounds: 30, PlayerHealth: 20, Stings: 12},
+		{Won: false, Rounds: 40, PlayerHealth: -2, Stings: 35},
+	}
+
+	output := &bytes.Buffer{}
+	printSimulationHistograms(output, results, 60, asciiHistogram)
+
+	_, stingsToDeath, _ := strings.Cut(output.String(), "💀 Stings to Death")
+	if strings.Contains(stingsToDeath, "12 |") || !strings.Contains(stingsToDeath, "35 |") {
+		t.Errorf("Expected only the lost game in the stings to death. Received:\n%s", stingsToDeath)
+	}
+}
+
+// TestTerminalWidth ensures the width falls back to $COLUMNS, and then to the default,
+// when stdout isn't a terminal.
+func TestTerminalWidth(t *testing.T) {
+	if _, ok := stdoutWidth(); ok {
+		t.Skip("stdout is a terminal")
+	}
+
+	t.Setenv("COLUMNS", "123")
+	if width := terminalWidth(); width != 123 {
+		t.Errorf("Expected the width from $COLUMNS. Received: %d", width)
+	}
+
+	t.Setenv("COLUMNS", "")
+	if width := terminalWidth(); width != defaultTerminalWidth {
+		t.Errorf("Expected the default width. Received: %d", width)
+	}
+}
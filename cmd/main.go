@@ -1,17 +1,112 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"math/rand/v2"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
 )
 
+// subcommands holds every mode the game can run in besides an interactive game,
+// keyed by the name given as the first argument. Each returns flag.ErrHelp once it
+// printed its usage because help was asked for.
+var subcommands = map[string]func(args []string, output io.Writer) error{
+	"fairness": runFairness,
+	"odds":     runOdds,
+	"replay":   runReplay,
+	"simulate": runSimulate,
+	"tune":     runTune,
+}
+
 func main() {
-	communication := game.StartupServer()
-	client := createClient(communication, os.Stdin, os.Stdout, func(err error) {
+	if len(os.Args) > 1 {
+		if subcommand, ok := subcommands[os.Args[1]]; ok {
+			if err := subcommand(os.Args[2:], os.Stdout); err != nil && !errors.Is(err, flag.ErrHelp) {
+				log.Fatalln(err)
+			}
+
+			return
+		}
+	}
+
+	seed := flag.Uint64("seed", 0, "seed for the game's random rolls (random when omitted)")
+	rules := flag.String("rules", "", "path to a JSON or TOML ruleset file (standard rules when omitted)")
+	replays := flag.String("replays", "replays", "directory the replay of the game is written to")
+	flag.Parse()
+
+	if !isFlagSetIn(flag.CommandLine, "seed") {
+		*seed = rand.Uint64()
+	}
+
+	config, err := loadConfig(*rules)
+	if err != nil {
 		log.Fatalln(err)
+	}
+
+	ctx := context.Background()
+
+	var clientErr error
+
+	recorder := game.NewRecorder(game.StartupServer(ctx, config, *seed, nil), *seed, config)
+	client := createClient(recorder, os.Stdin, os.Stdout, func(err error) {
+		clientErr = err
+	})
+
+	// The items the player starts with can be listed before the first event arrives.
+	client.state = game.NewGameState(config, *seed)
+
+	client.run(ctx)
+
+	writeReplay(recorder.Replay(), *replays)
+
+	if clientErr != nil {
+		log.Fatalln(clientErr)
+	}
+}
+
+// writeReplay stores the replay of a game in the given directory, unless nothing happened in the game.
+func writeReplay(replay game.Replay, directory string) {
+	if len(replay.Commands) == 0 && replay.Start == nil {
+		return
+	}
+
+	path := filepath.Join(directory, fmt.Sprintf("%s-%d.json", time.Now().Format("20060102-150405"), replay.Seed))
+
+	if err := replay.WriteFile(path); err != nil {
+		log.Printf("Could not save the replay: %s\n", err)
+		return
+	}
+
+	fmt.Printf("Replay saved to %s. Watch it again with: replay %s\n", path, path)
+}
+
+// loadConfig returns the rules in the ruleset file at path, or the standard rules if no
+// path was given.
+func loadConfig(path string) (game.GameConfig, error) {
+	if path == "" {
+		return game.DefaultGameConfig(), nil
+	}
+
+	return game.LoadRuleset(path)
+}
+
+// isFlagSetIn reports whether the named flag was explicitly passed to flags.
+func isFlagSetIn(flags *flag.FlagSet, name string) bool {
+	set := false
+
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
 	})
 
-	client.run()
+	return set
 }
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"testing"
+)
+
+// TestSubcommandsHelp ensures every subcommand answers -h with flag.ErrHelp, which main
+// treats as success, after printing how it is used.
+func TestSubcommandsHelp(t *testing.T) {
+	for name, subcommand := range subcommands {
+		output := &bytes.Buffer{}
+
+		if err := subcommand([]string{"-h"}, output); !errors.Is(err, flag.ErrHelp) {
+			t.Errorf("Expected %s -h to ask for help. Received: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"runtime"
+	"time"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+// runOdds works out the exact chance of winning a game and how long it is expected to
+// last, either from the start of a game or from a saved one.
+func runOdds(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("odds", flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	rules := flags.String("rules", "", "path to a JSON or TOML ruleset file (standard rules when omitted)")
+	save := flags.String("save", "", "path to a saved game to work out the odds from (a new game when omitted)")
+	games := flags.Int("simulate", 0, "number of games to simulate for comparison (none when omitted)")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of games simulated at the same time")
+	seed := flags.Uint64("seed", 0, "seed of the first simulated game (random when omitted)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if !isFlagSetIn(flags, "seed") {
+		*seed = rand.Uint64()
+	}
+
+	if *save != "" && *games > 0 {
+		return errors.New("simulated games always start from a new game, so they can't be compared with a saved one")
+	}
+
+	if *save != "" && *rules != "" {
+		return errors.New("a saved game is played by the rules it was saved with, so -rules can't be used with -save")
+	}
+
+	var config game.GameConfig
+	var state game.GameState
+
+	if *save != "" {
+		saved, err := game.ReadSavedGame(*save)
+		if err != nil {
+			return err
+		}
+
+		config, state = saved.Config, saved.State
+	} else {
+		loaded, err := loadConfig(*rules)
+		if err != nil {
+			return err
+		}
+
+		config, state = loaded, game.NewGameState(loaded, *seed)
+	}
+
+	start := time.Now()
+
+	solution, err := game.Solve(config, state)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(output, `🔮 Exact Odds
+============================
+Win chance    : %.4f%%
+Rounds left   : %.2f expected
+Game states   : %d solved in %s
+`,
+		solution.WinProbability*100,
+		solution.ExpectedRounds,
+		solution.States,
+		time.Since(start).Round(time.Millisecond),
+	)
+
+	if *games <= 0 {
+		return nil
+	}
+
+	results, err := game.Simulate(context.Background(), config, *games, *workers, *seed)
+	if err != nil {
+		return err
+	}
+
+	report := game.Summarize(results)
+
+	fmt.Fprintf(output, `
+🎲 Simulated
+----------------------------
+Win rate      : %.4f%% (%d of %d)
+Rounds        : %.2f on average
+`,
+		report.WinRate*100,
+		report.Wins,
+		report.Games,
+		report.Rounds.Mean,
+	)
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+// TestRunOdds ensures the odds subcommand solves new and saved games, and compares them
+// with simulated games when asked.
+func TestRunOdds(t *testing.T) {
+	directory := t.TempDir()
+
+	rules := filepath.Join(directory, "small.toml")
+	config, err := game.DefaultGameConfig().WithSetting("drone.count", 3)
+	if err == nil {
+		config, err = config.WithSetting("worker.count", 2)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := config.MarshalRuleset("toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(rules, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := &bytes.Buffer{}
+	if err := runOdds([]string{"-rules", rules, "-simulate", "200", "-seed", "4"}, output); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, expected := range []string{"🔮 Exact Odds", "Win chance    :", "Rounds left   :", "🎲 Simulated", "of 200)"} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("Expected the output to contain %q. Received:\n%s", expected, output.String())
+		}
+	}
+
+	// Save a game part of the way through and work out the odds from there.
+	ctx := context.Background()
+	communication := game.StartupServer(ctx, config, 4, nil)
+	defer communication.Quit()
+
+	communication.Hit(ctx)
+	communication.WaitForCPU(ctx)
+
+	save, err := communication.Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(directory, "saved.json")
+	if err := save.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	output.Reset()
+	if err := runOdds([]string{"-save", path}, output); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !strings.Contains(output.String(), "Win chance") {
+		t.Errorf("Expected the odds of the saved game. Received:\n%s", output.String())
+	}
+
+	if err := runOdds([]string{"-save", path, "-simulate", "10"}, output); err == nil {
+		t.Error("Expected an error comparing a saved game with simulated ones.")
+	}
+
+	if err := runOdds([]string{"-save", path, "-rules", rules}, output); err == nil || !strings.Contains(err.Error(), "-rules can't be used with -save") {
+		t.Errorf("Expected an error solving a saved game by other rules. Received: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+// runReplay plays a recorded game back through the game server, printing every event
+// and the summary of the game just as it appeared when it was played.
+func runReplay(args []string, output io.Writer) error {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "-help" || args[0] == "--help") {
+		fmt.Fprintln(output, "usage: replay <file>")
+		return flag.ErrHelp
+	}
+
+	if len(args) != 1 {
+		return errors.New("usage: replay <file>")
+	}
+
+	replay, err := game.ReadReplay(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(output, "▶️ Replaying game %d (%d commands)\n", replay.Seed, len(replay.Commands))
+
+	if replay.Start != nil {
+		fmt.Fprintf(output, "Resuming from a saved game at round %d.\n", replay.Start.State.Round)
+	}
+
+	fmt.Fprintln(output)
+
+	var lastEvent game.Event
+
+	err = replay.Play(context.Background(), func(event game.Event) {
+		fmt.Fprintln(output, event.Message)
+		lastEvent = event
+	})
+	if err != nil {
+		return err
+	}
+
+	if !lastEvent.Finished {
+		fmt.Fprintln(output, "\nThe recording ends before the game was finished.")
+		return nil
+	}
+
+	client := &Client{writer: output}
+	client.printGameSummary(lastEvent.State)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+// TestRunReplay records a game through the client and checks that the replay subcommand
+// prints the same game back, ending with its summary.
+func TestRunReplay(t *testing.T) {
+	ctx := context.Background()
+	config := game.DefaultGameConfig()
+	recorder := game.NewRecorder(game.StartupServer(ctx, config, 11, nil), 11, config)
+
+	played := &bytes.Buffer{}
+	client := createClient(recorder, strings.NewReader("auto\n"), played, func(err error) {})
+	client.run(ctx)
+
+	path := filepath.Join(t.TempDir(), "game.json")
+	if err := recorder.Replay().WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := &bytes.Buffer{}
+	if err := runReplay([]string{path}, replayed); err != nil {
+		t.Fatalf("Unexpected error replaying the game: %s", err)
+	}
+
+	// Everything after the intro and the prompt should be identical.
+	_, playedGame, _ := strings.Cut(played.String(), "begin...\n> ")
+	_, replayedGame, _ := strings.Cut(replayed.String(), "commands)\n\n")
+
+	if playedGame != replayedGame {
+		t.Errorf("Expected the replay to match the original game.\nPlayed:\n%s\nReplayed:\n%s", playedGame, replayedGame)
+	}
+
+	if err := runReplay(nil, replayed); err == nil {
+		t.Error("Expected an error when no replay file is given.")
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+// runSimulate plays a batch of games without any input and reports how the player fared,
+// so that changes to the rules can be judged before anybody plays them.
+func runSimulate(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	games := flags.Int("n", 1000, "number of games to simulate")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of games played at the same time")
+	seed := flags.Uint64("seed", 0, "seed of the first game, the rest follow on from it (random when omitted)")
+	rules := flags.String("rules", "", "path to a JSON or TOML ruleset file (standard rules when omitted)")
+	histograms := flags.Bool("histograms", true, "draw histograms of the rounds, final health and stings to death")
+	width := flags.Int("width", 0, "width of the histograms in characters (the terminal's width when omitted)")
+	blocks := flags.Bool("blocks", false, "draw the histograms with Unicode block characters, down to eighths of a character")
+	export := flags.String("export", "", "path to write every game's result to, as CSV (.csv) or newline-delimited JSON (.ndjson)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if !isFlagSetIn(flags, "seed") {
+		*seed = rand.Uint64()
+	}
+
+	config, err := loadConfig(*rules)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	results, err := game.Simulate(context.Background(), config, *games, *workers, *seed)
+	if err != nil {
+		return err
+	}
+
+	printSimulationReport(output, game.Summarize(results), *seed, time.Since(start))
+
+	if *histograms {
+		if *width <= 0 {
+			*width = terminalWidth()
+		}
+
+		style := asciiHistogram
+		if *blocks {
+			style = blockHistogram
+		}
+
+		printSimulationHistograms(output, results, *width, style)
+	}
+
+	if *export != "" {
+		if err := exportResults(results, *export); err != nil {
+			return fmt.Errorf("could not export the results: %w", err)
+		}
+
+		fmt.Fprintf(output, "\nResults of every game written to %s.\n", *export)
+	}
+
+	return nil
+}
+
+// exportResults writes the result of every game to path, in the format its extension names.
+func exportResults(results []game.GameResult, path string) error {
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	if format == "jsonl" {
+		format = "ndjson"
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := game.ExportResults(file, results, format); err != nil {
+		file.Close()
+		os.Remove(path)
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// printSimulationReport displays the outcome of a batch of simulated games.
+func printSimulationReport(output io.Writer, report game.SimulationReport, seed uint64, elapsed time.Duration) {
+	fmt.Fprintf(output, `🎲 Simulation Report
+============================
+Games played  : %d (seeds %d to %d)
+Time taken    : %s
+Win rate      : %.2f%% (%d of %d)
+
+              %8s %6s %6s %6s %6s
+`,
+		report.Games,
+		seed,
+		seed+uint64(max(report.Games-1, 0)),
+		elapsed.Round(time.Millisecond),
+		report.WinRate*100,
+		report.Wins,
+		report.Games,
+		"mean", "p50", "p90", "p99", "max",
+	)
+
+	for _, row := range []struct {
+		name         string
+		distribution game.Distribution
+	}{
+		{"Rounds", report.Rounds},
+		{"Hits", report.Hits},
+		{"Stings", report.Stings},
+	} {
+		fmt.Fprintf(output, "%-14s%8.1f %6d %6d %6d %6d\n",
+			row.name,
+			row.distribution.Mean,
+			row.distribution.P50,
+			row.distribution.P90,
+			row.distribution.P99,
+			row.distribution.Max,
+		)
+	}
+}
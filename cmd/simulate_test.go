@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRunSimulate ensures the simulate subcommand reports on the games it played.
+func TestRunSimulate(t *testing.T) {
+	output := &bytes.Buffer{}
+
+	if err := runSimulate([]string{"-n", "20", "-workers", "3", "-seed", "5"}, output); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, expected := range []string{"🎲 Simulation Report", "Games played  : 20 (seeds 5 to 24)", "Win rate      :", "Rounds", "Hits", "Stings", "⏱️ Rounds to Finish", "❤️ Final Player Health", "💀 Stings to Death"} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("Expected the report to contain %q. Received:\n%s", expected, output.String())
+		}
+	}
+
+	output.Reset()
+
+	if err := runSimulate([]string{"-n", "5", "-histograms=false"}, output); err != nil || strings.Contains(output.String(), "Rounds to Finish") {
+		t.Errorf("Expected no histograms. Received: %v\n%s", err, output.String())
+	}
+
+	if err := runSimulate([]string{"-workers", "0"}, output); err == nil {
+		t.Error("Expected an error without any workers.")
+	}
+
+	if err := runSimulate([]string{"-rules", "missing.toml"}, output); err == nil {
+		t.Error("Expected an error for a missing ruleset.")
+	}
+}
+
+// TestRunSimulateExport ensures the result of every game can be exported for analysis.
+func TestRunSimulateExport(t *testing.T) {
+	directory := t.TempDir()
+
+	for path, expected := range map[string]string{
+		filepath.Join(directory, "results.csv"):    "seed,outcome,rounds",
+		filepath.Join(directory, "results.ndjson"): `{"seed":3,`,
+		filepath.Join(directory, "results.jsonl"):  `{"seed":3,`,
+	} {
+		output := &bytes.Buffer{}
+
+		if err := runSimulate([]string{"-n", "4", "-seed", "3", "-export", path}, output); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.HasPrefix(string(data), expected) || strings.Count(string(data), "\n") < 4 {
+			t.Errorf("Unexpected export to %s:\n%s", path, data)
+		}
+
+		if !strings.Contains(output.String(), "Results of every game written to "+path) {
+			t.Errorf("Expected the export to be reported. Received:\n%s", output.String())
+		}
+	}
+
+	path := filepath.Join(directory, "results.xml")
+	if err := runSimulate([]string{"-n", "4", "-export", path}, &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for an unsupported export format.")
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		t.Error("Expected no file to be left behind by a failed export.")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package main
+
+// stdoutWidth can't ask the terminal for its size on this platform, so it always
+// returns false.
+func stdoutWidth() (int, bool) {
+	return 0, false
+}
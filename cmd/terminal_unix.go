@@ -0,0 +1,30 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the size of a terminal, as the TIOCGWINSZ ioctl reports it.
+type winsize struct {
+	rows    uint16
+	columns uint16
+	xPixels uint16
+	yPixels uint16
+}
+
+// stdoutWidth asks the terminal stdout is drawn in for its width. It returns false if
+// stdout isn't a terminal.
+func stdoutWidth() (int, bool) {
+	var size winsize
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 || size.columns == 0 {
+		return 0, false
+	}
+
+	return int(size.columns), true
+}
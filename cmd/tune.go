@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+// runTune searches for a ruleset with which the player wins as often as asked, and
+// writes it out ready to be played with --rules.
+func runTune(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("tune", flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	target := flags.Float64("target", 45, "percentage of games the player should win")
+	tolerance := flags.Float64("tolerance", 2, "how many percentage points the win rate may be off by")
+	vary := flags.String("vary", "", `settings to tune and their ranges, e.g. "drone.health=10:120,player.miss_chance=0:50"`)
+	games := flags.Int("n", 2000, "number of games simulated for every ruleset tried")
+	workers := flags.Int("workers", runtime.NumCPU(), "number of games played at the same time")
+	seed := flags.Uint64("seed", 0, "seed of the first game in every batch (random when omitted)")
+	rules := flags.String("rules", "", "path to the JSON or TOML ruleset to start from (standard rules when omitted)")
+	out := flags.String("o", "", "path to write the tuned JSON or TOML ruleset to (printed as TOML when omitted)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if !isFlagSetIn(flags, "seed") {
+		*seed = rand.Uint64()
+	}
+
+	parameters, err := parseTuningParameters(*vary)
+	if err != nil {
+		return err
+	}
+
+	config, err := loadConfig(*rules)
+	if err != nil {
+		return err
+	}
+
+	goal := game.TuningGoal{WinRate: *target / 100, Tolerance: *tolerance / 100}
+
+	result, err := game.Tune(context.Background(), config, parameters, goal, *games, *workers, *seed)
+	if err != nil {
+		return err
+	}
+
+	verdict := fmt.Sprintf("The player wins %.2f%% of %d simulated games (target %.2f%% ± %.2f%%) after trying %d rulesets.",
+		result.WinRate*100, *games, *target, *tolerance, result.Evaluations)
+	if !result.Reached {
+		verdict += " The target could not be reached within the given ranges; this is the closest ruleset found."
+	}
+
+	if *out == "" {
+		data, err := result.Config.MarshalRuleset("toml")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(output, "# %s\n\n%s", verdict, data)
+
+		return nil
+	}
+
+	data, err := result.Config.MarshalRuleset(strings.TrimPrefix(filepath.Ext(*out), "."))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(output, "%s\nRuleset written to %s. Play it with: --rules %s\n", verdict, *out, *out)
+
+	return nil
+}
+
+// parseTuningParameters reads a comma separated list of settings to tune, each written as
+// setting=min:max.
+func parseTuningParameters(list string) ([]game.TuningParameter, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, fmt.Errorf("no settings to tune, pass them with -vary, e.g. -vary drone.health=10:120")
+	}
+
+	var parameters []game.TuningParameter
+
+	for _, entry := range strings.Split(list, ",") {
+		setting, valueRange, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		minText, maxText, rangeOk := strings.Cut(valueRange, ":")
+
+		minimum, minErr := strconv.Atoi(minText)
+		maximum, maxErr := strconv.Atoi(maxText)
+
+		if !ok || !rangeOk || minErr != nil || maxErr != nil {
+			return nil, fmt.Errorf("invalid setting to tune %q, expected setting=min:max", entry)
+		}
+
+		parameters = append(parameters, game.TuningParameter{Setting: setting, Min: minimum, Max: maximum})
+	}
+
+	return parameters, nil
+}
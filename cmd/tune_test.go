@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PsionicAlch/BeesInTheTrap/internal/game"
+)
+
+// TestRunTune ensures the tune subcommand writes a ruleset that can be loaded again.
+func TestRunTune(t *testing.T) {
+	output := &bytes.Buffer{}
+	path := filepath.Join(t.TempDir(), "tuned.json")
+
+	args := []string{"-vary", "drone.health=10:120", "-target", "30", "-tolerance", "10", "-n", "100", "-seed", "1", "-o", path}
+	if err := runTune(args, output); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !strings.Contains(output.String(), "Ruleset written to "+path) {
+		t.Errorf("Expected the output to name the ruleset. Received:\n%s", output.String())
+	}
+
+	if _, err := game.LoadRuleset(path); err != nil {
+		t.Errorf("Expected the tuned ruleset to load. Received: %s", err)
+	}
+
+	output.Reset()
+
+	if err := runTune([]string{"-vary", "drone.health=10:120", "-n", "50", "-seed", "1"}, output); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !strings.HasPrefix(output.String(), "# The player wins") || !strings.Contains(output.String(), "[drone]") {
+		t.Errorf("Expected a TOML ruleset to be printed. Received:\n%s", output.String())
+	}
+}
+
+// TestParseTuningParameters ensures settings to tune are read from the -vary flag.
+func TestParseTuningParameters(t *testing.T) {
+	parameters, err := parseTuningParameters("drone.health=10:120, player.miss_chance=0:50")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []game.TuningParameter{{Setting: "drone.health", Min: 10, Max: 120}, {Setting: "player.miss_chance", Min: 0, Max: 50}}
+	if len(parameters) != 2 || parameters[0] != expected[0] || parameters[1] != expected[1] {
+		t.Errorf("Expected %+v. Received: %+v", expected, parameters)
+	}
+
+	for _, list := range []string{"", "drone.health", "drone.health=10", "drone.health=a:b"} {
+		if _, err := parseTuningParameters(list); err == nil {
+			t.Errorf("Expected an error for %q.", list)
+		}
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/PsionicAlch/BeesInTheTrap
 
 go 1.24.1
+
+require github.com/BurntSushi/toml v1.6.0
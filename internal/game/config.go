@@ -0,0 +1,186 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// BeeStats holds the rules for a single type of bee: how tough it is, how hard it
+// stings, how often it misses and how many of them live in the hive.
+type BeeStats struct {
+	Health        int  // Hit points each bee of this type starts with.
+	DamageTaken   int  // Hit points a bee of this type loses when the player hits it, before the player's damage spread.
+	DamageDealt   int  // Hit points the player loses when a bee of this type stings, before its damage spread.
+	DamageSpread  uint // Percentage the damage of a sting may be rolled above or below DamageDealt.
+	MissChance    uint // Percentage chance that a sting misses the player.
+	CritChance    uint // Percentage chance that a sting that lands is a critical hit.
+	AimMissChance uint // Percentage chance that the player misses when aiming at a bee of this type.
+	DropChance    uint // Percentage chance that a bee of this type drops an item when it dies.
+	EffectChance  uint // Percentage chance that a sting from a bee of this type inflicts its status effect.
+	AbilityChance uint // Percentage chance that a bee of this type uses its ability instead of stinging, when it can.
+	Count         uint // Number of bees of this type in the hive.
+}
+
+// GameConfig is the ruleset a game is played with. It describes the player, the makeup
+// of the hive and how it grows, so variants of the game can be run without changing the
+// engine.
+type GameConfig struct {
+	PlayerHealth       int
+	PlayerMissChance   uint          // Percentage chance that the player's attack misses the hive.
+	PlayerCritChance   uint          // Percentage chance that the player's attack that lands is a critical hit.
+	PlayerDamageSpread uint          // Percentage the damage of the player's attack may be rolled above or below the bee type's DamageTaken.
+	PlayerDefense      uint          // Percentage of a sting's damage the player blocks while defending.
+	PlayerEffectChance uint          // Percentage chance that the player's hit leaves the bee with its type's hit effect.
+	Loadout            map[Item]uint // Number of each item the player starts with.
+	Bees               map[BeeType]BeeStats
+	SpawnInterval      uint // Rounds between the clutches of eggs the queen lays. She never lays any if it is 0.
+	SpawnCount         uint // Number of drones that hatch from each clutch.
+	SpawnCap           uint // Most living drones the hive can hold before the queen's eggs stop hatching.
+}
+
+// DefaultGameConfig returns the standard ruleset: a 100 HP player who misses 10% of
+// the time and carries one of every item, facing the hive described by the bee type
+// registry, whose queen lays a drone every 3 rounds once fewer than 8 are left.
+func DefaultGameConfig() GameConfig {
+	bees := make(map[BeeType]BeeStats, len(beeDefinitions))
+	for beeType, definition := range beeDefinitions {
+		bees[beeType] = definition.BeeStats
+	}
+
+	return GameConfig{
+		PlayerHealth:       100,
+		PlayerMissChance:   10,
+		PlayerCritChance:   0,
+		PlayerDamageSpread: 0,
+		PlayerDefense:      50,
+		PlayerEffectChance: 0,
+		Loadout:            map[Item]uint{HealingSalve: 1, SmokeCanister: 1, Antihistamine: 1},
+		Bees:               bees,
+		SpawnInterval:      3,
+		SpawnCount:         1,
+		SpawnCap:           8,
+	}
+}
+
+// beeTypes returns the bee types used by the config in ascending order so that
+// anything built from it comes out the same way on every run.
+func (config GameConfig) beeTypes() []BeeType {
+	return slices.Sorted(maps.Keys(config.Bees))
+}
+
+// hasAbilities reports whether bees of any type may use their abilities.
+func (config GameConfig) hasAbilities() bool {
+	for _, stats := range config.Bees {
+		if stats.AbilityChance > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate reports every rule in the config that would make the game unplayable.
+// Settings are named the way they appear in ruleset files, e.g. "worker.count".
+func (config GameConfig) Validate() error {
+	var errs []error
+
+	if config.PlayerHealth <= 0 {
+		errs = append(errs, errors.New("player.health must be > 0"))
+	}
+
+	if config.PlayerMissChance > 100 {
+		errs = append(errs, errors.New("player.miss_chance must be between 0 and 100"))
+	}
+
+	if config.PlayerCritChance > 100 {
+		errs = append(errs, errors.New("player.crit_chance must be between 0 and 100"))
+	}
+
+	if config.PlayerDamageSpread > 100 {
+		errs = append(errs, errors.New("player.damage_spread must be between 0 and 100"))
+	}
+
+	if config.PlayerDefense > 100 {
+		errs = append(errs, errors.New("player.defense must be between 0 and 100"))
+	}
+
+	if config.PlayerEffectChance > 100 {
+		errs = append(errs, errors.New("player.effect_chance must be between 0 and 100"))
+	}
+
+	for _, item := range slices.Sorted(maps.Keys(config.Loadout)) {
+		if item.Key() == "" {
+			errs = append(errs, fmt.Errorf("unknown item %d", item))
+		}
+	}
+
+	if config.SpawnInterval > 0 && config.SpawnCount == 0 {
+		errs = append(errs, errors.New("hive.spawn_count must be > 0 while hive.spawn_interval is set"))
+	}
+
+	if config.SpawnInterval > 0 && config.SpawnCap == 0 {
+		errs = append(errs, errors.New("hive.spawn_cap must be > 0 while hive.spawn_interval is set"))
+	}
+
+	if len(config.Bees) == 0 {
+		errs = append(errs, errors.New("the hive must contain at least one type of bee"))
+	}
+
+	for _, beeType := range config.beeTypes() {
+		key := beeType.key()
+		if key == "" {
+			errs = append(errs, fmt.Errorf("unknown bee type %d", beeType))
+			continue
+		}
+
+		stats := config.Bees[beeType]
+
+		if stats.Health <= 0 {
+			errs = append(errs, fmt.Errorf("%s.health must be > 0", key))
+		}
+
+		if stats.DamageTaken <= 0 {
+			errs = append(errs, fmt.Errorf("%s.damage_taken must be > 0", key))
+		}
+
+		if stats.DamageDealt < 0 {
+			errs = append(errs, fmt.Errorf("%s.damage_dealt must be >= 0", key))
+		}
+
+		if stats.MissChance > 100 {
+			errs = append(errs, fmt.Errorf("%s.miss_chance must be between 0 and 100", key))
+		}
+
+		if stats.DamageSpread > 100 {
+			errs = append(errs, fmt.Errorf("%s.damage_spread must be between 0 and 100", key))
+		}
+
+		if stats.CritChance > 100 {
+			errs = append(errs, fmt.Errorf("%s.crit_chance must be between 0 and 100", key))
+		}
+
+		if stats.AimMissChance > 100 {
+			errs = append(errs, fmt.Errorf("%s.aim_miss_chance must be between 0 and 100", key))
+		}
+
+		if stats.DropChance > 100 {
+			errs = append(errs, fmt.Errorf("%s.drop_chance must be between 0 and 100", key))
+		}
+
+		if stats.EffectChance > 100 {
+			errs = append(errs, fmt.Errorf("%s.effect_chance must be between 0 and 100", key))
+		}
+
+		if stats.AbilityChance > 100 {
+			errs = append(errs, fmt.Errorf("%s.ability_chance must be between 0 and 100", key))
+		}
+
+		if stats.Count == 0 {
+			errs = append(errs, fmt.Errorf("%s.count must be > 0", key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
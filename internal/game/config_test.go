@@ -0,0 +1,87 @@
+package game
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestDefaultGameConfig ensures the default ruleset matches the standard game and that
+// every registered bee type is part of it.
+func TestDefaultGameConfig(t *testing.T) {
+	config := DefaultGameConfig()
+
+	if config.PlayerHealth != 100 {
+		t.Errorf("Default config has incorrect player health. Expected: %d. Received: %d.", 100, config.PlayerHealth)
+	}
+
+	if config.PlayerMissChance != 10 {
+		t.Errorf("Default config has incorrect player miss chance. Expected: %d. Received: %d.", 10, config.PlayerMissChance)
+	}
+
+	for beeType, definition := range beeDefinitions {
+		if config.Bees[beeType] != definition.BeeStats {
+			t.Errorf("Default config has incorrect stats for the %s. Expected: %+v. Received: %+v.", beeType, definition.BeeStats, config.Bees[beeType])
+		}
+	}
+
+	// Changing a config must never leak into the registry or other configs.
+	config.Bees[QueenBee] = BeeStats{}
+
+	if DefaultGameConfig().Bees[QueenBee] != beeDefinitions[QueenBee].BeeStats {
+		t.Error("Modifying a config changed the default ruleset.")
+	}
+}
+
+// TestGameConfigBeeTypes ensures bee types are returned in a stable, ascending order.
+func TestGameConfigBeeTypes(t *testing.T) {
+	expected := []BeeType{QueenBee, WorkerBee, DroneBee}
+
+	for range 10 {
+		if received := DefaultGameConfig().beeTypes(); !slices.Equal(received, expected) {
+			t.Fatalf("Unexpected bee type order. Expected: %v. Received: %v.", expected, received)
+		}
+	}
+}
+
+// TestGameConfigValidate ensures that unplayable configs are rejected and the default is accepted.
+func TestGameConfigValidate(t *testing.T) {
+	if err := DefaultGameConfig().Validate(); err != nil {
+		t.Errorf("Expected the default config to be valid. Received: %s", err)
+	}
+
+	scenarios := []struct {
+		modify      func(config *GameConfig)
+		expectedErr string
+	}{
+		{func(config *GameConfig) { config.PlayerHealth = 0 }, "player.health must be > 0"},
+		{func(config *GameConfig) { config.PlayerMissChance = 101 }, "player.miss_chance must be between 0 and 100"},
+		{func(config *GameConfig) { config.PlayerDefense = 101 }, "player.defense must be between 0 and 100"},
+		{func(config *GameConfig) { config.PlayerEffectChance = 101 }, "player.effect_chance must be between 0 and 100"},
+		{func(config *GameConfig) { config.Bees = nil }, "the hive must contain at least one type of bee"},
+		{func(config *GameConfig) { config.Bees[BeeType(9)] = BeeStats{} }, "unknown bee type 9"},
+		{func(config *GameConfig) { config.Bees[WorkerBee] = BeeStats{} }, "worker.count must be > 0"},
+		{func(config *GameConfig) { config.Bees[DroneBee] = BeeStats{DamageDealt: -1} }, "drone.damage_dealt must be >= 0"},
+		{func(config *GameConfig) { config.Bees[QueenBee] = BeeStats{MissChance: 200} }, "queen.miss_chance must be between 0 and 100"},
+		{func(config *GameConfig) { config.Bees[WorkerBee] = BeeStats{AimMissChance: 101} }, "worker.aim_miss_chance must be between 0 and 100"},
+		{func(config *GameConfig) { config.Bees[DroneBee] = BeeStats{DropChance: 101} }, "drone.drop_chance must be between 0 and 100"},
+		{func(config *GameConfig) { config.Bees[DroneBee] = BeeStats{EffectChance: 101} }, "drone.effect_chance must be between 0 and 100"},
+		{func(config *GameConfig) { config.Bees[DroneBee] = BeeStats{AbilityChance: 101} }, "drone.ability_chance must be between 0 and 100"},
+		{func(config *GameConfig) { config.Bees[DroneBee] = BeeStats{CritChance: 101} }, "drone.crit_chance must be between 0 and 100"},
+		{func(config *GameConfig) { config.Bees[DroneBee] = BeeStats{DamageSpread: 101} }, "drone.damage_spread must be between 0 and 100"},
+		{func(config *GameConfig) { config.PlayerDamageSpread = 101 }, "player.damage_spread must be between 0 and 100"},
+		{func(config *GameConfig) { config.Loadout[Item(7)] = 1 }, "unknown item 7"},
+		{func(config *GameConfig) { config.SpawnInterval, config.SpawnCount, config.SpawnCap = 3, 0, 10 }, "hive.spawn_count must be > 0 while hive.spawn_interval is set"},
+		{func(config *GameConfig) { config.SpawnInterval, config.SpawnCount, config.SpawnCap = 3, 2, 0 }, "hive.spawn_cap must be > 0 while hive.spawn_interval is set"},
+	}
+
+	for _, scenario := range scenarios {
+		config := DefaultGameConfig()
+		scenario.modify(&config)
+
+		err := config.Validate()
+		if err == nil || !strings.Contains(err.Error(), scenario.expectedErr) {
+			t.Errorf("Expected error to contain \"%s\". Received: %v", scenario.expectedErr, err)
+		}
+	}
+}
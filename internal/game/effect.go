@@ -0,0 +1,127 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Effect represents a status effect that lasts a number of rounds on the player or a bee.
+type Effect uint
+
+const (
+	// Poisoned loses poisonDamage hit points at the start of every round.
+	Poisoned Effect = iota
+
+	// Stunned can't act: a stunned player skips their turn and a stunned bee can't sting.
+	Stunned
+
+	// Enraged deals more damage but misses more often.
+	Enraged
+
+	// Smoked misses more often.
+	Smoked
+
+	effectCount
+)
+
+const (
+	// poisonDamage is the hit points lost to poison at the start of every round.
+	poisonDamage = 5
+
+	// enragedDamageBonus is the percentage added to the damage an enraged attacker deals.
+	enragedDamageBonus = 50
+
+	// enragedMissChance is added to the miss chance of an enraged attacker.
+	enragedMissChance = 20
+
+	// smokedMissChance is added to the miss chance of a smoked attacker.
+	smokedMissChance = 30
+)
+
+// effectNames holds the name of every effect, used in messages and the summary.
+var effectNames = map[Effect]string{
+	Poisoned: "poisoned",
+	Stunned:  "stunned",
+	Enraged:  "enraged",
+	Smoked:   "smoked",
+}
+
+// String returns the name of the effect.
+func (effect Effect) String() string {
+	return effectNames[effect]
+}
+
+// Effects holds the number of rounds every status effect has left, indexed by Effect.
+// Effects last the number of rounds they were inflicted for, counting the round they
+// were inflicted in, and count down at the start of every round. Every bee carries its
+// own, so they are kept small.
+type Effects [effectCount]uint8
+
+// Has reports whether the effect is active.
+func (effects Effects) Has(effect Effect) bool {
+	return effect < effectCount && effects[effect] > 0
+}
+
+// inflict makes the effect last at least the given number of rounds, up to 255.
+func (effects *Effects) inflict(effect Effect, rounds uint) {
+	effects[effect] = max(effects[effect], uint8(min(rounds, math.MaxUint8)))
+}
+
+// cure ends the effect.
+func (effects *Effects) cure(effect Effect) {
+	effects[effect] = 0
+}
+
+// countDown takes a round off every active effect.
+func (effects *Effects) countDown() {
+	for effect := range effects {
+		if effects[effect] > 0 {
+			effects[effect]--
+		}
+	}
+}
+
+// String lists the active effects and the rounds they have left, e.g.
+// "poisoned (2 rounds), smoked (1 round)", or an empty string if there are none.
+func (effects Effects) String() string {
+	var active []string
+
+	for effect, rounds := range effects {
+		switch {
+		case rounds == 1:
+			active = append(active, fmt.Sprintf("%s (1 round)", Effect(effect)))
+		case rounds > 1:
+			active = append(active, fmt.Sprintf("%s (%d rounds)", Effect(effect), rounds))
+		}
+	}
+
+	return strings.Join(active, ", ")
+}
+
+// missChance returns the miss chance of an attacker with the effects, raised while it is
+// enraged or smoked. A stunned attacker always misses.
+func (effects Effects) missChance(missChance uint) uint {
+	if effects.Has(Stunned) {
+		return 100
+	}
+
+	if effects.Has(Enraged) {
+		missChance += enragedMissChance
+	}
+
+	if effects.Has(Smoked) {
+		missChance += smokedMissChance
+	}
+
+	return min(missChance, 100)
+}
+
+// damage returns the damage an attacker with the effects deals, raised while it is enraged.
+func (effects Effects) damage(damage int) int {
+	if effects.Has(Enraged) {
+		damage += damage * enragedDamageBonus / 100
+	}
+
+	return damage
+}
@@ -0,0 +1,69 @@
+package game
+
+import "testing"
+
+// TestEffects ensures effects last at least as long as they were last inflicted for and
+// count down to nothing.
+func TestEffects(t *testing.T) {
+	var effects Effects
+
+	effects.inflict(Poisoned, 3)
+	effects.inflict(Poisoned, 1)
+	effects.inflict(Smoked, 1)
+
+	if effects[Poisoned] != 3 || !effects.Has(Smoked) || effects.Has(Stunned) {
+		t.Fatalf("Expected the poison to keep its longer duration. Received: %v", effects)
+	}
+
+	if text := effects.String(); text != "poisoned (3 rounds), smoked (1 round)" {
+		t.Fatalf("Unexpected description of the effects: %q", text)
+	}
+
+	effects.countDown()
+
+	if effects[Poisoned] != 2 || effects.Has(Smoked) {
+		t.Fatalf("Expected every effect to lose a round. Received: %v", effects)
+	}
+
+	effects.cure(Poisoned)
+
+	if effects != (Effects{}) || effects.String() != "" || effects.Has(effectCount) {
+		t.Errorf("Expected no effects to be left. Received: %v", effects)
+	}
+}
+
+// TestEffectsMissChanceAndDamage ensures effects change the attacker's miss chance and
+// damage, never beyond certain.
+func TestEffectsMissChanceAndDamage(t *testing.T) {
+	scenarios := []struct {
+		effects    []Effect
+		missChance uint
+		damage     int
+	}{
+		{nil, 10, 10},
+		{[]Effect{Enraged}, 10 + enragedMissChance, 15},
+		{[]Effect{Smoked}, 10 + smokedMissChance, 10},
+		{[]Effect{Enraged, Smoked}, 10 + enragedMissChance + smokedMissChance, 15},
+		{[]Effect{Stunned}, 100, 10},
+		{[]Effect{Poisoned}, 10, 10},
+	}
+
+	for _, scenario := range scenarios {
+		var effects Effects
+		for _, effect := range scenario.effects {
+			effects.inflict(effect, 1)
+		}
+
+		if missChance, damage := effects.missChance(10), effects.damage(10); missChance != scenario.missChance || damage != scenario.damage {
+			t.Errorf("%v: expected a miss chance of %d and %d damage. Received: %d, %d", effects, scenario.missChance, scenario.damage, missChance, damage)
+		}
+	}
+
+	var effects Effects
+	effects.inflict(Smoked, 1)
+	effects.inflict(Enraged, 1)
+
+	if missChance := effects.missChance(90); missChance != 100 {
+		t.Errorf("Expected the miss chance to be capped at 100. Received: %d", missChance)
+	}
+}
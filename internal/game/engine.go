@@ -0,0 +1,646 @@
+package game
+
+import (
+	"math/rand/v2"
+)
+
+// The engine core applies the rules of the game to a GameState. It has no knowledge of
+// the protocol, messages or the journal, and doesn't allocate, so the same rules drive
+// both the GameServer and games played headless.
+
+const (
+	// critDamage is the percentage of the damage rolled that a critical hit deals.
+	critDamage = 150
+
+	// feedHealing is the hit points a bee feeds back to the queen.
+	feedHealing = 10
+
+	// swarmSize is the most bees that sting together in a swarm.
+	swarmSize = 3
+
+	// swarmDamage is the damage dealt by each sting of a swarm that lands.
+	swarmDamage = 2
+)
+
+// attack plays the player's attack: a bee is picked from the hive, the miss roll is made
+// and, if the attack lands, the bee takes damage. The player's status effects change how
+// often they miss and how hard they hit. A bee that dies is removed from the hive, unless
+// it was the last living queen. QueenKilled is returned when it was, in which case the
+// hive must collapse.
+func attack(state *GameState, config GameConfig, random *rand.Rand) (EventType, Outcome) {
+	beeIndex := random.IntN(len(state.Hive))
+
+	return strike(state, config, random, beeIndex, rollMiss(random), state.Player.Effects.missChance(state.Player.MissChance))
+}
+
+// aimedAttack plays the player's attack on a bee of the target type, picked from the
+// living bees of that type. The attack misses if the roll is below the target type's
+// aim miss chance rather than the player's own. The hive must have a living bee of the
+// target type.
+func aimedAttack(state *GameState, config GameConfig, random *rand.Rand, target BeeType) (EventType, Outcome) {
+	picked := random.IntN(livingBees(state.Hive, target))
+
+	beeIndex := 0
+	for index, bee := range state.Hive {
+		if bee.Type != target || bee.Health <= 0 {
+			continue
+		}
+
+		if picked == 0 {
+			beeIndex = index
+			break
+		}
+
+		picked--
+	}
+
+	missChance := state.Player.Effects.missChance(config.Bees[target].AimMissChance)
+
+	eventType, outcome := strike(state, config, random, beeIndex, rollMiss(random), missChance)
+	outcome.Aimed = true
+
+	return eventType, outcome
+}
+
+// strike resolves the player's attack on the bee at beeIndex with the given miss roll.
+// The damage is spread around the bee type's DamageTaken and may be a critical hit. A bee
+// that survives may be left with its type's hit effect, and one that dies may drop an
+// item for the player.
+func strike(state *GameState, config GameConfig, random *rand.Rand, beeIndex int, roll, missChance uint) (EventType, Outcome) {
+	selectedBee := &state.Hive[beeIndex]
+
+	outcome := Outcome{
+		BeeIndex:     beeIndex,
+		BeeType:      selectedBee.Type,
+		Roll:         roll,
+		HealthBefore: selectedBee.Health,
+		HealthAfter:  selectedBee.Health,
+	}
+
+	if outcome.Roll < missChance {
+		return PlayerMissed, outcome
+	}
+
+	state.Hits += 1
+
+	stats := config.Bees[selectedBee.Type]
+	outcome.Damage, outcome.Critical = rollDamage(random, stats.DamageTaken, config.PlayerDamageSpread, config.PlayerCritChance)
+	outcome.Damage = state.Player.Effects.damage(outcome.Damage)
+	beeDied := selectedBee.takeDamage(outcome.Damage)
+	outcome.HealthAfter = selectedBee.Health
+
+	if !beeDied {
+		afflictBee(config, random, selectedBee, &outcome)
+		return BeeHit, outcome
+	}
+
+	dropItem(state, config, random, selectedBee.Type, &outcome)
+
+	if selectedBee.Type == QueenBee && !hasLivingQueen(state.Hive) {
+		return QueenKilled, outcome
+	}
+
+	// Order in the hive doesn't matter, so the dead bee is replaced by the last one.
+	last := len(state.Hive) - 1
+	state.Hive[beeIndex] = state.Hive[last]
+	state.Hive = state.Hive[:last]
+
+	return BeeKilled, outcome
+}
+
+// dropItem rolls for a bee of the given type that just died to drop an item, which the
+// player picks up. Nothing is rolled for bees that never drop anything.
+func dropItem(state *GameState, config GameConfig, random *rand.Rand, beeType BeeType, outcome *Outcome) {
+	chance := config.Bees[beeType].DropChance
+	if chance == 0 || random.UintN(100) >= chance {
+		return
+	}
+
+	outcome.Item = Item(random.IntN(len(itemDefinitions)))
+	outcome.ItemDropped = true
+
+	state.Player.addItem(outcome.Item)
+}
+
+// afflictBee rolls for the player's hit on a bee that survived it to inflict the bee
+// type's hit effect on it. Nothing is rolled if the player's hits never inflict anything.
+func afflictBee(config GameConfig, random *rand.Rand, bee *Bee, outcome *Outcome) {
+	chance := config.PlayerEffectChance
+	if chance == 0 || random.UintN(100) >= chance {
+		return
+	}
+
+	definition := beeDefinitions[bee.Type]
+	bee.Effects.inflict(definition.hitEffect, definition.hitEffectRounds)
+
+	outcome.Effect = definition.hitEffect
+	outcome.EffectInflicted = true
+}
+
+// use plays the player's turn when they use an item instead of attacking. The item is
+// taken out of the player's inventory, which must hold one.
+func use(state *GameState, config GameConfig, item Item) (EventType, Outcome) {
+	player := &state.Player
+	player.removeItem(item)
+
+	outcome := Outcome{Item: item, HealthBefore: player.Health}
+
+	switch item {
+	case HealingSalve:
+		player.Health = max(min(player.Health+salveHealing, config.PlayerHealth), player.Health)
+	case SmokeCanister:
+		for index := range state.Hive {
+			state.Hive[index].Effects.inflict(Smoked, smokeRounds)
+		}
+	case Antihistamine:
+		player.Immune = true
+		player.Effects.cure(Poisoned)
+	}
+
+	outcome.HealthAfter = player.Health
+
+	return ItemUsed, outcome
+}
+
+// defend plays the player's turn when they brace themselves instead of attacking. Part
+// of the damage of the hive's next sting is blocked.
+func defend(state *GameState) (EventType, Outcome) {
+	state.Player.Defending = true
+
+	return PlayerDefended, Outcome{HealthBefore: state.Player.Health, HealthAfter: state.Player.Health}
+}
+
+// hiveMove plays the hive's turn: a bee is picked from the hive and, by its type's
+// ability chance, uses its type's ability instead of stinging the player. A player who
+// was defending lowers their guard once the hive has moved, whatever it did.
+func hiveMove(state *GameState, config GameConfig, random *rand.Rand) (EventType, Outcome) {
+	defer func() { state.Player.Defending = false }()
+
+	beeIndex := random.IntN(len(state.Hive))
+
+	if rollAbility(state, config, random, beeIndex) {
+		switch beeDefinitions[state.Hive[beeIndex].Type].ability {
+		case Feed:
+			return feed(state, config, beeIndex)
+		case Swarm:
+			return swarm(state, random, beeIndex)
+		case RoyalCommand:
+			return command(state, beeIndex)
+		}
+	}
+
+	return stingBy(state, config, random, beeIndex)
+}
+
+// rollAbility rolls for the bee at beeIndex to use its type's ability. Nothing is rolled
+// for bee types that never use theirs, or when the bee can't use it: a stunned bee can't
+// do anything, a queen must be hungry to be fed and a royal command can't be issued
+// while the last one stands.
+func rollAbility(state *GameState, config GameConfig, random *rand.Rand, beeIndex int) bool {
+	bee := state.Hive[beeIndex]
+
+	chance := config.Bees[bee.Type].AbilityChance
+	if chance == 0 || bee.Effects.Has(Stunned) {
+		return false
+	}
+
+	switch beeDefinitions[bee.Type].ability {
+	case Feed:
+		if hungryQueen(state.Hive, config) < 0 {
+			return false
+		}
+	case RoyalCommand:
+		if state.Commanded {
+			return false
+		}
+	}
+
+	return random.UintN(100) < chance
+}
+
+// feed plays the turn of the bee at beeIndex feeding a hungry queen, who recovers up to
+// feedHealing hit points but never more than she started with.
+func feed(state *GameState, config GameConfig, beeIndex int) (EventType, Outcome) {
+	queen := &state.Hive[hungryQueen(state.Hive, config)]
+
+	outcome := Outcome{
+		BeeIndex:     beeIndex,
+		BeeType:      state.Hive[beeIndex].Type,
+		HealthBefore: queen.Health,
+	}
+
+	queen.Health = min(queen.Health+feedHealing, config.Bees[QueenBee].Health)
+	outcome.HealthAfter = queen.Health
+
+	return QueenFed, outcome
+}
+
+// swarm plays the turn of the bee at beeIndex leading a swarm: it and the first living
+// bees of its type in the hive that aren't stunned, up to swarmSize of them, sting the
+// player at once. Each sting rolls its own miss and those that land deal swarmDamage,
+// raised while the bee is enraged. They never land critical hits or inflict status
+// effects. The player takes the damage of the whole swarm as a single sting.
+func swarm(state *GameState, random *rand.Rand, beeIndex int) (EventType, Outcome) {
+	leader := state.Hive[beeIndex]
+	player := &state.Player
+
+	outcome := Outcome{
+		BeeIndex:     beeIndex,
+		BeeType:      leader.Type,
+		HealthBefore: player.Health,
+		HealthAfter:  player.Health,
+	}
+
+	commanded := state.Commanded
+	state.Commanded = false
+
+	damage := swarmSting(leader, commanded, random, &outcome)
+
+	for index, bee := range state.Hive {
+		if outcome.Swarm == swarmSize {
+			break
+		}
+
+		if index == beeIndex || bee.Type != leader.Type || bee.Health <= 0 || bee.Effects.Has(Stunned) {
+			continue
+		}
+
+		damage += swarmSting(bee, commanded, random, &outcome)
+	}
+
+	if outcome.SwarmStings == 0 {
+		return BeesSwarmed, outcome
+	}
+
+	state.Stings += outcome.SwarmStings
+
+	playerDied := player.takeDamage(damage)
+	outcome.HealthAfter = player.Health
+	outcome.Damage = outcome.HealthBefore - outcome.HealthAfter
+	outcome.Blocked = damage - outcome.Damage
+
+	if playerDied {
+		return PlayerKilled, outcome
+	}
+
+	return BeesSwarmed, outcome
+}
+
+// swarmSting rolls the sting of a bee in a swarm, counts it in the outcome and returns
+// the damage it deals. Under a royal command, the sting can't miss.
+func swarmSting(bee Bee, commanded bool, random *rand.Rand, outcome *Outcome) int {
+	outcome.Swarm += 1
+
+	if roll := rollMiss(random); !commanded && roll < stingMissChance(bee) {
+		return 0
+	}
+
+	outcome.SwarmStings += 1
+
+	return bee.Effects.damage(swarmDamage)
+}
+
+// command plays the turn of the bee at beeIndex issuing a royal command, which makes the
+// hive's next sting certain to land.
+func command(state *GameState, beeIndex int) (EventType, Outcome) {
+	state.Commanded = true
+
+	return QueenCommanded, Outcome{
+		BeeIndex:     beeIndex,
+		BeeType:      state.Hive[beeIndex].Type,
+		HealthBefore: state.Player.Health,
+		HealthAfter:  state.Player.Health,
+	}
+}
+
+// sting plays the hive's attack: a bee is picked from the hive and stings the player. A
+// player who was defending lowers their guard once the sting has been made, whether it
+// landed or not.
+func sting(state *GameState, config GameConfig, random *rand.Rand) (EventType, Outcome) {
+	defer func() { state.Player.Defending = false }()
+
+	return stingBy(state, config, random, random.IntN(len(state.Hive)))
+}
+
+// stingBy resolves the sting of the bee at beeIndex: the miss roll is made and, if the
+// sting lands, the player takes damage spread around the bee type's DamageDealt, which
+// may be a critical hit, and may be inflicted with the bee type's status effect. The
+// bee's own status effects change how often it misses and how hard it stings. Under a
+// royal command, a sting can't miss unless the bee is stunned, and the command is
+// carried out once it is made.
+func stingBy(state *GameState, config GameConfig, random *rand.Rand, beeIndex int) (EventType, Outcome) {
+	selectedBee := &state.Hive[beeIndex]
+	player := &state.Player
+
+	outcome := Outcome{
+		BeeIndex:     beeIndex,
+		BeeType:      selectedBee.Type,
+		Roll:         rollMiss(random),
+		HealthBefore: player.Health,
+		HealthAfter:  player.Health,
+	}
+
+	missChance := stingMissChance(*selectedBee)
+	if state.Commanded && !selectedBee.Effects.Has(Stunned) {
+		missChance = 0
+		state.Commanded = false
+	}
+
+	if outcome.Roll < missChance {
+		return BeeMissed, outcome
+	}
+	state.Stings += 1
+
+	stats := config.Bees[selectedBee.Type]
+
+	damage, critical := rollDamage(random, stats.DamageDealt, stats.DamageSpread, stats.CritChance)
+	damage = selectedBee.Effects.damage(damage)
+	outcome.Critical = critical
+
+	playerDied := player.takeDamage(damage)
+	outcome.HealthAfter = player.Health
+	outcome.Damage = outcome.HealthBefore - outcome.HealthAfter
+	outcome.Blocked = damage - outcome.Damage
+
+	if playerDied {
+		return PlayerKilled, outcome
+	}
+
+	if outcome.Damage > 0 {
+		inflictEffect(state, config, random, selectedBee.Type, &outcome)
+	}
+
+	return PlayerStung, outcome
+}
+
+// stingMissChance returns the chance the bee misses the player, given the status effects
+// it is under.
+func stingMissChance(bee Bee) uint {
+	return bee.Effects.missChance(bee.MissChance)
+}
+
+// inflictEffect rolls for a sting from a bee of the given type that hurt the player to
+// inflict the type's status effect on them. Nothing is rolled for bee types whose stings
+// never inflict anything.
+func inflictEffect(state *GameState, config GameConfig, random *rand.Rand, beeType BeeType, outcome *Outcome) {
+	chance := config.Bees[beeType].EffectChance
+	if chance == 0 || random.UintN(100) >= chance {
+		return
+	}
+
+	definition := beeDefinitions[beeType]
+	state.Player.Effects.inflict(definition.stingEffect, definition.stingEffectRounds)
+
+	outcome.Effect = definition.stingEffect
+	outcome.EffectInflicted = true
+}
+
+// stunned plays the player's turn when they are stunned and can't do anything.
+func stunned(state *GameState) (EventType, Outcome) {
+	return PlayerStunned, Outcome{HealthBefore: state.Player.Health, HealthAfter: state.Player.Health}
+}
+
+// tick starts a round: the status effects on the player and every bee count down, and
+// poison that is still active hurts whoever it's on. Unless report is nil, it is called
+// with a PlayerPoisoned or BeePoisoned outcome for everyone the poison hurt.
+//
+// Poison can kill. A bee that dies of poison is removed from the hive, unless it was the
+// last living queen, in which case true is returned and the hive must collapse. A player
+// who dies of poison loses the game.
+func tick(state *GameState, report func(EventType, Outcome)) bool {
+	queenKilled := false
+
+	player := &state.Player
+	player.Effects.countDown()
+
+	if player.Effects.Has(Poisoned) {
+		outcome := Outcome{Damage: poisonDamage, HealthBefore: player.Health}
+		player.Health -= poisonDamage
+		outcome.HealthAfter = player.Health
+
+		if report != nil {
+			report(PlayerPoisoned, outcome)
+		}
+	}
+
+	for index := 0; index < len(state.Hive); {
+		bee := &state.Hive[index]
+
+		// Most bees are never under any effect, so they are skipped as cheaply as possible.
+		if bee.Effects == (Effects{}) {
+			index++
+			continue
+		}
+
+		bee.Effects.countDown()
+
+		if bee.Health <= 0 || !bee.Effects.Has(Poisoned) {
+			index++
+			continue
+		}
+
+		outcome := Outcome{BeeIndex: index, BeeType: bee.Type, Damage: poisonDamage, HealthBefore: bee.Health}
+		beeDied := bee.takeDamage(poisonDamage)
+		outcome.HealthAfter = bee.Health
+
+		if report != nil {
+			report(BeePoisoned, outcome)
+		}
+
+		lastQueen := beeDied && bee.Type == QueenBee && !hasLivingQueen(state.Hive)
+		queenKilled = queenKilled || lastQueen
+
+		if !beeDied || lastQueen {
+			index++
+			continue
+		}
+
+		// The bee that takes the dead one's place hasn't ticked yet.
+		last := len(state.Hive) - 1
+		state.Hive[index] = state.Hive[last]
+		state.Hive = state.Hive[:last]
+	}
+
+	return queenKilled
+}
+
+// hatch lets the queen's eggs hatch at the start of every SpawnInterval rounds while she
+// is alive: up to SpawnCount new drones join the hive, as long as it holds fewer than
+// SpawnCap living drones. It returns false if none hatched.
+func hatch(state *GameState, config GameConfig) (Outcome, bool) {
+	if config.SpawnInterval == 0 || state.Round%config.SpawnInterval != 0 || !hasLivingQueen(state.Hive) {
+		return Outcome{}, false
+	}
+
+	stats, ok := config.Bees[DroneBee]
+	living := uint(livingBees(state.Hive, DroneBee))
+	if !ok || living >= config.SpawnCap {
+		return Outcome{}, false
+	}
+
+	outcome := Outcome{
+		BeeIndex: len(state.Hive),
+		BeeType:  DroneBee,
+		Hatched:  min(config.SpawnCount, config.SpawnCap-living),
+	}
+
+	for range outcome.Hatched {
+		state.Hive = append(state.Hive, Bee{
+			Type:       DroneBee,
+			Health:     stats.Health,
+			MissChance: stats.MissChance,
+		})
+	}
+
+	return outcome, outcome.Hatched > 0
+}
+
+// collapse kills every bee left in the hive once it has lost its queen. The bees stay in
+// the hive with no hit points left so the final state shows the collapse.
+func collapse(state *GameState) {
+	for index := range state.Hive {
+		bee := &state.Hive[index]
+
+		if bee.Health > 0 {
+			bee.Health = 0
+			state.Collapsed += 1
+		}
+	}
+}
+
+// rollDamage rolls the damage of an attack that landed, up to spread percent above or
+// below damage, and whether it is a critical hit, which deals critDamage percent of it.
+// Nothing is rolled for damage without a spread or for attackers that never land
+// critical hits.
+func rollDamage(random *rand.Rand, damage int, spread, critChance uint) (int, bool) {
+	if deviation := damage * int(spread) / 100; deviation > 0 {
+		damage += random.IntN(2*deviation+1) - deviation
+	}
+
+	if critChance == 0 || random.UintN(100) >= critChance {
+		return damage, false
+	}
+
+	return damage * critDamage / 100, true
+}
+
+// rollMiss rolls a number from 0 to 99 for an attack. The attack misses if the roll is
+// below the attacker's miss chance, so a miss chance of N% misses exactly N times in 100.
+func rollMiss(random *rand.Rand) uint {
+	return random.UintN(100)
+}
+
+// hasDeadQueen reports whether the hive still holds a queen with no hit points left, which
+// only happens once the last living queen has died and the hive must collapse.
+func hasDeadQueen(hive []Bee) bool {
+	for _, bee := range hive {
+		if bee.Type == QueenBee && bee.Health <= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hungryQueen returns the index of the first living queen in the hive with fewer hit
+// points than she started with, or -1 if there is none.
+func hungryQueen(hive []Bee, config GameConfig) int {
+	for index, bee := range hive {
+		if bee.Type == QueenBee && bee.Health > 0 && bee.Health < config.Bees[QueenBee].Health {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// hasLivingQueen reports whether any queen in the hive still has hit points left.
+func hasLivingQueen(hive []Bee) bool {
+	for _, bee := range hive {
+		if bee.Type == QueenBee && bee.Health > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// livingBees returns the number of bees of the given type in the hive with hit points left.
+func livingBees(hive []Bee, beeType BeeType) int {
+	count := 0
+	for _, bee := range hive {
+		if bee.Type == beeType && bee.Health > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// headlessGame plays complete games without a client or a protocol. It reuses its memory
+// from one game to the next, so playing a game doesn't allocate.
+type headlessGame struct {
+	config   GameConfig
+	beeTypes []BeeType
+	source   *rand.PCG
+	random   *rand.Rand
+	state    GameState
+}
+
+// createHeadlessGame prepares to play games by the rules in config.
+func createHeadlessGame(config GameConfig) *headlessGame {
+	source := rand.NewPCG(0, 0)
+
+	return &headlessGame{
+		config:   config,
+		beeTypes: config.beeTypes(),
+		source:   source,
+		random:   rand.New(source),
+	}
+}
+
+// play plays a game with the given seed to the end, attacking every round the player
+// isn't stunned, and returns its final state. The state plays out exactly as it would on
+// a GameServer started from NewSource(seed), but without a journal. It is only valid
+// until the next game is played.
+func (game *headlessGame) play(seed uint64) *GameState {
+	game.source.Seed(seed, seed)
+
+	state := &game.state
+	*state = GameState{
+		Seed:   seed,
+		Player: fillPlayer(state.Player.Inventory, game.config),
+		Hive:   fillHive(state.Hive, game.config, game.beeTypes),
+	}
+
+	for {
+		state.Round += 1
+
+		queenKilled := tick(state, nil)
+		hatch(state, game.config)
+
+		switch {
+		case state.Player.Health <= 0:
+			return state
+		case queenKilled:
+			collapse(state)
+			return state
+		case len(state.Hive) == 0:
+			return state
+		}
+
+		if !state.Player.Effects.Has(Stunned) {
+			switch eventType, _ := attack(state, game.config, game.random); {
+			case eventType == QueenKilled:
+				collapse(state)
+				return state
+			case len(state.Hive) == 0:
+				return state
+			}
+		}
+
+		if eventType, _ := hiveMove(state, game.config, game.random); eventType == PlayerKilled {
+			return state
+		}
+	}
+}
@@ -0,0 +1,724 @@
+package game
+
+import (
+	"context"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+// TestAttack ensures the player's attack applies the rules to the state it's given.
+func TestAttack(t *testing.T) {
+	config := DefaultGameConfig()
+	random := rand.New(highSource)
+
+	// The high source always picks the last bee and never misses.
+	state := GameState{
+		Player: Player{Health: 100},
+		Hive: []Bee{
+			{Type: WorkerBee, Health: 75},
+			{Type: QueenBee, Health: 100},
+			{Type: DroneBee, Health: 30},
+		},
+	}
+
+	eventType, outcome := attack(&state, config, random)
+	if eventType != BeeKilled || outcome.BeeIndex != 2 || outcome.Damage != 30 || state.Hits != 1 {
+		t.Fatalf("Expected the drone to be killed. Received: %s, %+v", eventType, outcome)
+	}
+
+	if len(state.Hive) != 2 || state.Hive[0].Type != WorkerBee || state.Hive[1].Type != QueenBee {
+		t.Fatalf("Expected the dead drone to be removed from the hive. Received: %+v", state.Hive)
+	}
+
+	eventType, outcome = attack(&state, config, random)
+	if eventType != BeeHit || outcome.HealthBefore != 100 || outcome.HealthAfter != 90 {
+		t.Fatalf("Expected the queen to be hit. Received: %s, %+v", eventType, outcome)
+	}
+
+	state.Hive[1].Health = 10
+
+	if eventType, _ = attack(&state, config, random); eventType != QueenKilled || len(state.Hive) != 2 {
+		t.Fatalf("Expected the last queen to be killed and left in the hive. Received: %s, %+v", eventType, state.Hive)
+	}
+
+	state.Player.MissChance = 100
+	if eventType, _ = attack(&state, config, random); eventType != PlayerMissed || state.Hits != 3 {
+		t.Errorf("Expected the player to miss. Received: %s", eventType)
+	}
+}
+
+// TestAimedAttack ensures an aimed attack only lands on a living bee of the target type and
+// misses by the target type's aim miss chance rather than the player's.
+func TestAimedAttack(t *testing.T) {
+	config := DefaultGameConfig()
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player: Player{Health: 100, MissChance: 100},
+		Hive: []Bee{
+			{Type: WorkerBee, Health: 75},
+			{Type: DroneBee, Health: 60},
+			{Type: QueenBee, Health: 100},
+			{Type: DroneBee, Health: 60},
+		},
+	}
+
+	// The high source picks the last drone and rolls above the drone's aim miss chance.
+	eventType, outcome := aimedAttack(&state, config, random, DroneBee)
+	if eventType != BeeHit || outcome.BeeIndex != 3 || outcome.BeeType != DroneBee || !outcome.Aimed || state.Hive[3].Health != 30 {
+		t.Fatalf("Expected the last drone to be hit. Received: %s, %+v", eventType, outcome)
+	}
+
+	queen := config.Bees[QueenBee]
+	queen.AimMissChance = 100
+	config.Bees[QueenBee] = queen
+
+	eventType, outcome = aimedAttack(&state, config, random, QueenBee)
+	if eventType != PlayerMissed || outcome.BeeIndex != 2 || !outcome.Aimed || state.Hits != 1 {
+		t.Errorf("Expected the aimed attack on the queen to miss. Received: %s, %+v", eventType, outcome)
+	}
+}
+
+// TestSting ensures the hive's attack applies the rules to the state it's given.
+func TestSting(t *testing.T) {
+	config := DefaultGameConfig()
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player: Player{Health: 6},
+		Hive:   []Bee{{Type: QueenBee, Health: 100}, {Type: WorkerBee, Health: 75}},
+	}
+
+	if eventType, outcome := sting(&state, config, random); eventType != PlayerStung || outcome.Damage != 5 || state.Player.Health != 1 || state.Stings != 1 {
+		t.Fatalf("Expected the worker to sting the player. Received: %s, %+v", eventType, outcome)
+	}
+
+	state.Hive[1].MissChance = 100
+	if eventType, _ := sting(&state, config, random); eventType != BeeMissed || state.Player.Health != 1 {
+		t.Fatalf("Expected the worker to miss. Received: %s", eventType)
+	}
+
+	state.Hive[1].MissChance = 0
+	if eventType, _ := sting(&state, config, random); eventType != PlayerKilled || state.Player.Health != -4 {
+		t.Errorf("Expected the worker to kill the player. Received: %s", eventType)
+	}
+}
+
+// TestDefendBlocksSting ensures defending blocks part of the next sting, and only the next sting.
+func TestDefendBlocksSting(t *testing.T) {
+	config := DefaultGameConfig()
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player: createPlayer(config),
+		Hive:   []Bee{{Type: WorkerBee, Health: 75}},
+	}
+
+	if eventType, _ := defend(&state); eventType != PlayerDefended || !state.Player.Defending {
+		t.Fatalf("Expected the player to defend. Received: %s", eventType)
+	}
+
+	eventType, outcome := sting(&state, config, random)
+	if eventType != PlayerStung || outcome.Damage != 2 || outcome.Blocked != 3 || state.Player.Health != 98 {
+		t.Fatalf("Expected the player's defense to block 3 of the worker's 5 damage. Received: %s, %+v", eventType, outcome)
+	}
+
+	if state.Player.Defending {
+		t.Fatal("Expected the player to lower their guard after the sting.")
+	}
+
+	if _, outcome = sting(&state, config, random); outcome.Damage != 5 || outcome.Blocked != 0 {
+		t.Errorf("Expected the next sting to deal full damage. Received: %+v", outcome)
+	}
+
+	// A sting that misses still ends the player's defense.
+	defend(&state)
+	state.Hive[0].MissChance = 100
+
+	if eventType, _ = sting(&state, config, random); eventType != BeeMissed || state.Player.Defending {
+		t.Errorf("Expected the player to lower their guard after a miss. Received: %s", eventType)
+	}
+}
+
+// TestUse ensures every item has its effect and is taken out of the inventory.
+func TestUse(t *testing.T) {
+	config := DefaultGameConfig()
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player: createPlayer(config),
+		Hive:   []Bee{{Type: QueenBee, Health: 100, MissChance: 75}},
+	}
+
+	state.Player.Health = 90
+
+	if eventType, outcome := use(&state, config, HealingSalve); eventType != ItemUsed || outcome.Item != HealingSalve || outcome.HealthAfter != 100 || state.Player.Health != 100 {
+		t.Fatalf("Expected the salve to heal the player up to their starting health. Received: %s, %+v", eventType, outcome)
+	}
+
+	if _, ok := state.Player.Inventory[HealingSalve]; ok {
+		t.Fatalf("Expected the used salve to be taken out of the inventory. Received: %v", state.Player.Inventory)
+	}
+
+	// The high source rolls 99, which only misses through the smoke once the queen's miss
+	// chance is raised to 100. The smoke lasts for the round it was used in and the next two.
+	use(&state, config, SmokeCanister)
+
+	for round := range smokeRounds {
+		if round > 0 {
+			tick(&state, nil)
+		}
+
+		if eventType, _ := sting(&state, config, random); eventType != BeeMissed {
+			t.Fatalf("Expected the queen to miss through the smoke. Received: %s", eventType)
+		}
+	}
+
+	tick(&state, nil)
+
+	if eventType, _ := sting(&state, config, random); eventType != PlayerStung || state.Player.Health != 90 {
+		t.Fatalf("Expected the smoke to have cleared. Received: %s", eventType)
+	}
+
+	state.Player.Effects.inflict(Poisoned, 3)
+	use(&state, config, Antihistamine)
+
+	if state.Player.Effects.Has(Poisoned) {
+		t.Fatal("Expected the antihistamine to cure the poison.")
+	}
+
+	if eventType, outcome := sting(&state, config, random); eventType != PlayerStung || outcome.Damage != 0 || outcome.Blocked != 10 || state.Player.Immune {
+		t.Fatalf("Expected the antihistamine to take the sting out of the next sting. Received: %s, %+v", eventType, outcome)
+	}
+
+	if len(state.Player.Inventory) != 0 {
+		t.Errorf("Expected every item to be used up. Received: %v", state.Player.Inventory)
+	}
+}
+
+// TestDropItem ensures bees drop items by their drop chance and the player picks them up.
+func TestDropItem(t *testing.T) {
+	config := DefaultGameConfig()
+	random := rand.New(highSource)
+
+	drone := config.Bees[DroneBee]
+	drone.DropChance = 100
+	config.Bees[DroneBee] = drone
+
+	state := GameState{
+		Player: Player{Health: 100},
+		Hive:   []Bee{{Type: WorkerBee, Health: 10}, {Type: DroneBee, Health: 30}},
+	}
+
+	// The high source picks the last item.
+	eventType, outcome := attack(&state, config, random)
+	if eventType != BeeKilled || !outcome.ItemDropped || outcome.Item != Antihistamine || state.Player.Inventory[Antihistamine] != 1 {
+		t.Fatalf("Expected the drone to drop an antihistamine. Received: %s, %+v", eventType, outcome)
+	}
+
+	worker := config.Bees[WorkerBee]
+	worker.DropChance = 0
+	config.Bees[WorkerBee] = worker
+
+	if _, outcome = attack(&state, config, random); outcome.ItemDropped || len(state.Player.Inventory) != 1 {
+		t.Errorf("Expected the worker not to drop anything. Received: %+v", outcome)
+	}
+}
+
+// TestStatusEffects ensures the status effects of the attacker change how often it misses
+// and how hard it hits, for the player and the bees alike.
+func TestStatusEffects(t *testing.T) {
+	config := DefaultGameConfig()
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player: Player{Health: 100, MissChance: 75},
+		Hive:   []Bee{{Type: WorkerBee, Health: 75, MissChance: 75}},
+	}
+
+	// The high source rolls 99, so only a stunned attacker misses.
+	state.Player.Effects.inflict(Enraged, 1)
+
+	if eventType, outcome := attack(&state, config, random); eventType != BeeHit || outcome.Damage != 37 {
+		t.Fatalf("Expected the enraged player to hit for half as much again. Received: %s, %+v", eventType, outcome)
+	}
+
+	state.Hive[0].Effects.inflict(Enraged, 1)
+
+	if eventType, outcome := sting(&state, config, random); eventType != PlayerStung || outcome.Damage != 7 {
+		t.Fatalf("Expected the enraged worker to sting for half as much again. Received: %s, %+v", eventType, outcome)
+	}
+
+	state.Player.Effects.inflict(Stunned, 1)
+	state.Hive[0].Effects.inflict(Stunned, 1)
+
+	if eventType, _ := attack(&state, config, random); eventType != PlayerMissed {
+		t.Errorf("Expected the stunned player to miss. Received: %s", eventType)
+	}
+
+	if eventType, _ := sting(&state, config, random); eventType != BeeMissed {
+		t.Errorf("Expected the stunned worker to miss. Received: %s", eventType)
+	}
+}
+
+// TestInflictEffect ensures stings that hurt the player inflict the bee type's status
+// effect by its effect chance.
+func TestInflictEffect(t *testing.T) {
+	config := DefaultGameConfig()
+	random := rand.New(highSource)
+
+	worker := config.Bees[WorkerBee]
+	worker.EffectChance = 100
+	config.Bees[WorkerBee] = worker
+
+	state := GameState{
+		Player: Player{Health: 100},
+		Hive:   []Bee{{Type: WorkerBee, Health: 75}},
+	}
+
+	eventType, outcome := sting(&state, config, random)
+	if eventType != PlayerStung || !outcome.EffectInflicted || outcome.Effect != Poisoned || uint(state.Player.Effects[Poisoned]) != beeDefinitions[WorkerBee].stingEffectRounds {
+		t.Fatalf("Expected the worker to poison the player. Received: %s, %+v", eventType, outcome)
+	}
+
+	state.Player.Effects = Effects{}
+	state.Player.Immune = true
+
+	if _, outcome = sting(&state, config, random); outcome.EffectInflicted || state.Player.Effects.Has(Poisoned) {
+		t.Errorf("Expected a sting that didn't hurt not to poison the player. Received: %+v", outcome)
+	}
+}
+
+// TestAfflictBee ensures the player's hits leave the bees they don't kill with their
+// type's hit effect by the player's effect chance.
+func TestAfflictBee(t *testing.T) {
+	config := DefaultGameConfig()
+	config.PlayerEffectChance = 100
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player: Player{Health: 100},
+		Hive:   []Bee{{Type: WorkerBee, Health: 75}, {Type: DroneBee, Health: 60}},
+	}
+
+	// The high source picks the drone, the last bee in the hive.
+	eventType, outcome := attack(&state, config, random)
+	if eventType != BeeHit || !outcome.EffectInflicted || outcome.Effect != Stunned || uint(state.Hive[1].Effects[Stunned]) != beeDefinitions[DroneBee].hitEffectRounds {
+		t.Fatalf("Expected the hit to stun the drone. Received: %s, %+v", eventType, outcome)
+	}
+
+	// A stunned bee can't sting, nor use its ability.
+	config.Bees[DroneBee] = BeeStats{DamageTaken: 30, DamageDealt: 1, AbilityChance: 100, Count: 1}
+	if eventType, _ := hiveMove(&state, config, random); eventType != BeeMissed {
+		t.Errorf("Expected the stunned drone to miss. Received: %s", eventType)
+	}
+
+	eventType, outcome = attack(&state, config, random)
+	if eventType != BeeKilled || outcome.EffectInflicted {
+		t.Errorf("Expected a hit that kills not to inflict anything. Received: %s, %+v", eventType, outcome)
+	}
+
+	config.PlayerEffectChance = 0
+	if _, outcome = attack(&state, config, random); outcome.EffectInflicted || state.Hive[0].Effects != (Effects{}) {
+		t.Errorf("Expected hits not to inflict anything without an effect chance. Received: %+v", outcome)
+	}
+}
+
+// TestHiveMove ensures bees use their type's ability instead of stinging when they can,
+// and sting otherwise.
+func TestHiveMove(t *testing.T) {
+	config := DefaultGameConfig()
+	for _, setting := range []string{"queen.ability_chance", "worker.ability_chance", "drone.ability_chance"} {
+		config, _ = config.WithSetting(setting, 100)
+	}
+
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player: Player{Health: 100, Defending: true},
+		Hive:   []Bee{{Type: QueenBee, Health: 95}, {Type: WorkerBee, Health: 75}},
+	}
+
+	eventType, outcome := hiveMove(&state, config, random)
+	if eventType != QueenFed || outcome.BeeIndex != 1 || outcome.HealthBefore != 95 || outcome.HealthAfter != 100 || state.Hive[0].Health != 100 {
+		t.Fatalf("Expected the worker to feed the queen up to her starting health. Received: %s, %+v", eventType, outcome)
+	}
+
+	if state.Player.Defending {
+		t.Fatal("Expected the player to lower their guard once the queen was fed.")
+	}
+
+	if eventType, _ = hiveMove(&state, config, random); eventType != PlayerStung {
+		t.Fatalf("Expected the worker to sting once the queen was fed. Received: %s", eventType)
+	}
+
+	state.Hive[0], state.Hive[1] = state.Hive[1], state.Hive[0]
+	state.Player.Defending = true
+
+	if eventType, _ = hiveMove(&state, config, random); eventType != QueenCommanded || !state.Commanded || state.Player.Defending {
+		t.Fatalf("Expected the queen to issue a royal command and the player to lower their guard. Received: %s", eventType)
+	}
+
+	if eventType, _ = hiveMove(&state, config, random); eventType != PlayerStung || state.Commanded {
+		t.Fatalf("Expected the queen to sting while her command stands, carrying it out. Received: %s", eventType)
+	}
+
+	state.Hive[1].Effects.inflict(Stunned, 1)
+
+	if eventType, _ = hiveMove(&state, config, random); eventType != BeeMissed {
+		t.Errorf("Expected a stunned queen not to do anything. Received: %s", eventType)
+	}
+
+	state.Hive[1].Effects = Effects{}
+
+	if eventType, _ = hiveMove(&state, DefaultGameConfig(), random); eventType != PlayerStung {
+		t.Errorf("Expected the queen to sting without an ability chance. Received: %s", eventType)
+	}
+}
+
+// TestRoyalCommand ensures a royal command makes the next sting land, unless the bee is
+// stunned, in which case the command stands.
+func TestRoyalCommand(t *testing.T) {
+	config := DefaultGameConfig()
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player:    Player{Health: 100},
+		Hive:      []Bee{{Type: WorkerBee, Health: 75, MissChance: 100, Effects: Effects{Stunned: 1}}},
+		Commanded: true,
+	}
+
+	if eventType, _ := sting(&state, config, random); eventType != BeeMissed || !state.Commanded {
+		t.Fatalf("Expected a stunned bee to miss and leave the command standing. Received: %s", eventType)
+	}
+
+	state.Hive[0].Effects = Effects{}
+
+	if eventType, _ := sting(&state, config, random); eventType != PlayerStung || state.Commanded {
+		t.Fatalf("Expected the command to make a sure miss land. Received: %s", eventType)
+	}
+
+	if eventType, _ := sting(&state, config, random); eventType != BeeMissed {
+		t.Errorf("Expected the bee to miss once the command was carried out. Received: %s", eventType)
+	}
+}
+
+// TestSwarm ensures a swarm gathers the living bees of its leader's type that aren't
+// stunned and that the player takes all of its stings at once.
+func TestSwarm(t *testing.T) {
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player: Player{Health: 100, Defense: 50, Defending: true},
+		Hive: []Bee{
+			{Type: DroneBee, Health: 60, MissChance: 20},
+			{Type: DroneBee, Health: 60, MissChance: 20, Effects: Effects{Stunned: 1}},
+			{Type: WorkerBee, Health: 75, MissChance: 15},
+			{Type: DroneBee, Health: 60, MissChance: 20, Effects: Effects{Enraged: 1}},
+			{Type: DroneBee, Health: 60, MissChance: 20},
+			{Type: DroneBee, Health: 60, MissChance: 20},
+		},
+	}
+
+	eventType, outcome := swarm(&state, random, 5)
+	if eventType != BeesSwarmed || outcome.Swarm != swarmSize || outcome.SwarmStings != swarmSize || outcome.Damage != 3 || outcome.Blocked != 4 {
+		t.Fatalf("Expected three drones, one of them enraged, to sting the defending player for 3 damage. Received: %s, %+v", eventType, outcome)
+	}
+
+	if state.Stings != swarmSize || state.Player.Health != 97 {
+		t.Fatalf("Expected every sting of the swarm to count. Received: %+v", state)
+	}
+
+	state.Player.Defending = false
+
+	for index := range state.Hive {
+		state.Hive[index].MissChance = 100
+	}
+
+	if eventType, outcome = swarm(&state, random, 5); eventType != BeesSwarmed || outcome.SwarmStings != 0 || outcome.Damage != 0 || state.Player.Health != 97 {
+		t.Fatalf("Expected every sting of the swarm to miss. Received: %s, %+v", eventType, outcome)
+	}
+
+	state.Commanded = true
+	state.Player.Health = 5
+
+	if eventType, outcome = swarm(&state, random, 5); eventType != PlayerKilled || outcome.SwarmStings != swarmSize || state.Commanded {
+		t.Errorf("Expected the commanded swarm to land every sting and kill the player. Received: %s, %+v", eventType, outcome)
+	}
+}
+
+// TestHatch ensures the queen's eggs hatch every SpawnInterval rounds while she is alive,
+// up to the hive's cap of living drones.
+func TestHatch(t *testing.T) {
+	config := DefaultGameConfig()
+	config.SpawnInterval = 3
+	config.SpawnCount = 2
+	config.SpawnCap = 3
+
+	state := GameState{
+		Round: 2,
+		Hive:  []Bee{{Type: QueenBee, Health: 100}, {Type: DroneBee, Health: 60}},
+	}
+
+	if _, hatched := hatch(&state, config); hatched || len(state.Hive) != 2 {
+		t.Fatalf("Expected no eggs to hatch between clutches. Received: %+v", state.Hive)
+	}
+
+	state.Round = 3
+
+	outcome, hatched := hatch(&state, config)
+	if !hatched || outcome.Hatched != 2 || outcome.BeeIndex != 2 || outcome.BeeType != DroneBee || len(state.Hive) != 4 {
+		t.Fatalf("Expected two drones to hatch. Received: %+v, %+v", outcome, state.Hive)
+	}
+
+	if drone := state.Hive[3]; drone.Type != DroneBee || drone.Health != config.Bees[DroneBee].Health || drone.MissChance != config.Bees[DroneBee].MissChance {
+		t.Errorf("Expected a new drone with the drone's stats. Received: %+v", drone)
+	}
+
+	state.Round = 6
+	state.Hive[1].Health = 0
+	state.Hive = state.Hive[:3]
+
+	if outcome, _ = hatch(&state, config); outcome.Hatched != 2 {
+		t.Fatalf("Expected the hive to hatch up to its cap of living drones. Received: %+v", outcome)
+	}
+
+	state.Round = 9
+
+	if _, hatched = hatch(&state, config); hatched {
+		t.Fatalf("Expected no eggs to hatch once the hive is full. Received: %+v", state.Hive)
+	}
+
+	state.Hive = []Bee{{Type: QueenBee, Health: 0}}
+
+	if _, hatched = hatch(&state, config); hatched {
+		t.Errorf("Expected a dead queen not to lay eggs. Received: %+v", state.Hive)
+	}
+}
+
+// TestTick ensures status effects count down at the start of every round and that poison
+// hurts, and can kill, whoever it's on.
+func TestTick(t *testing.T) {
+	state := GameState{
+		Player: Player{Health: 100},
+		Hive: []Bee{
+			{Type: DroneBee, Health: 5},
+			{Type: QueenBee, Health: 5},
+			{Type: WorkerBee, Health: 75},
+		},
+	}
+
+	state.Player.Effects.inflict(Poisoned, 2)
+	state.Player.Effects.inflict(Stunned, 1)
+
+	for index := range state.Hive {
+		state.Hive[index].Effects.inflict(Poisoned, 2)
+	}
+
+	var reported []EventType
+	queenKilled := tick(&state, func(eventType EventType, outcome Outcome) {
+		reported = append(reported, eventType)
+	})
+
+	if !queenKilled {
+		t.Fatal("Expected the poison to kill the last queen.")
+	}
+
+	if state.Player.Health != 100-poisonDamage || state.Player.Effects.Has(Stunned) || state.Player.Effects[Poisoned] != 1 {
+		t.Fatalf("Expected the player to be poisoned and no longer stunned. Received: %+v", state.Player)
+	}
+
+	if !reflect.DeepEqual(reported, []EventType{PlayerPoisoned, BeePoisoned, BeePoisoned, BeePoisoned}) {
+		t.Fatalf("Expected the poison to report everyone it hurt. Received: %v", reported)
+	}
+
+	// The drone is replaced by the worker, and the dead queen stays for the hive to collapse.
+	if len(state.Hive) != 2 || state.Hive[0].Type != WorkerBee || state.Hive[0].Health != 70 || !hasDeadQueen(state.Hive) {
+		t.Fatalf("Expected the drone to die of poison and the queen to be left dead. Received: %+v", state.Hive)
+	}
+
+	if tick(&state, nil) {
+		t.Fatal("Expected the dead queen not to be killed again.")
+	}
+
+	if state.Player.Health != 100-poisonDamage || state.Player.Effects != (Effects{}) {
+		t.Errorf("Expected the poison to have worn off. Received: %+v", state.Player)
+	}
+}
+
+// TestCriticalHits ensures attacks that land can be critical hits for both sides, and
+// that their damage is spread around the usual amount.
+func TestCriticalHits(t *testing.T) {
+	config := DefaultGameConfig()
+	config.PlayerCritChance = 100
+
+	worker := config.Bees[WorkerBee]
+	worker.CritChance = 100
+	config.Bees[WorkerBee] = worker
+
+	random := rand.New(highSource)
+
+	state := GameState{
+		Player: Player{Health: 100},
+		Hive:   []Bee{{Type: WorkerBee, Health: 75}},
+	}
+
+	if eventType, outcome := attack(&state, config, random); eventType != BeeHit || !outcome.Critical || outcome.Damage != 37 {
+		t.Fatalf("Expected a critical hit for half as much damage again. Received: %s, %+v", eventType, outcome)
+	}
+
+	if eventType, outcome := sting(&state, config, random); eventType != PlayerStung || !outcome.Critical || outcome.Damage != 7 {
+		t.Fatalf("Expected a critical sting for half as much damage again. Received: %s, %+v", eventType, outcome)
+	}
+
+	config.PlayerCritChance = 0
+
+	if _, outcome := attack(&state, config, random); outcome.Critical || outcome.Damage != 25 {
+		t.Errorf("Expected a regular hit. Received: %+v", outcome)
+	}
+}
+
+// TestRollDamage ensures damage is spread evenly around the usual amount, and only rolled
+// when there is a spread.
+func TestRollDamage(t *testing.T) {
+	if damage, critical := rollDamage(rand.New(highSource), 30, 50, 0); damage != 45 || critical {
+		t.Fatalf("Expected the high source to roll the most damage. Received: %d, %t", damage, critical)
+	}
+
+	random := rand.New(NewSource(1))
+	seen := map[int]bool{}
+
+	for range 1000 {
+		damage, _ := rollDamage(random, 10, 20, 0)
+		if damage < 8 || damage > 12 {
+			t.Fatalf("Expected the damage to be within 20%% of 10. Received: %d", damage)
+		}
+
+		seen[damage] = true
+	}
+
+	if len(seen) != 5 {
+		t.Errorf("Expected every damage from 8 to 12 to be rolled. Received: %v", seen)
+	}
+
+	// A roll would change the source's state, so none is made without a spread or a crit chance.
+	source := rand.NewPCG(1, 1)
+	if damage, _ := rollDamage(rand.New(source), 1, 50, 0); damage != 1 || source.Uint64() != rand.NewPCG(1, 1).Uint64() {
+		t.Errorf("Expected no roll for damage too small to spread. Received: %d", damage)
+	}
+}
+
+// TestCollapse ensures every living bee dies with the queen and is counted.
+func TestCollapse(t *testing.T) {
+	state := GameState{Hive: []Bee{{Type: QueenBee, Health: 0}, {Type: WorkerBee, Health: 75}, {Type: DroneBee, Health: 30}}}
+
+	collapse(&state)
+
+	if state.Collapsed != 2 || hasLivingQueen(state.Hive) || state.Hive[1].Health != 0 || state.Hive[2].Health != 0 {
+		t.Errorf("Expected the hive to collapse. Received: %+v", state)
+	}
+}
+
+// TestHeadlessGame ensures headless games play out like games on a GameServer, and
+// that playing one doesn't allocate.
+func TestHeadlessGame(t *testing.T) {
+	// Attacks are spread, critical hits, status effects, abilities and the queen's eggs come
+	// into play in the second ruleset.
+	effects := DefaultGameConfig()
+	for _, setting := range []string{"player.crit_chance", "player.damage_spread", "player.effect_chance", "queen.effect_chance", "worker.effect_chance", "drone.effect_chance", "drone.crit_chance", "worker.damage_spread", "queen.ability_chance", "worker.ability_chance", "drone.ability_chance"} {
+		effects, _ = effects.WithSetting(setting, 50)
+	}
+
+	effects.SpawnInterval, effects.SpawnCount, effects.SpawnCap = 2, 3, 30
+
+	for _, config := range []GameConfig{DefaultGameConfig(), effects} {
+		game := createHeadlessGame(config)
+
+		for _, seed := range []uint64{1, 99} {
+			events, _ := recordConfiguredGame(t, config, seed)
+			expected := events[len(events)-1].State
+			received := game.play(seed)
+
+			if received.Round != expected.Round || received.Hits != expected.Hits || received.Stings != expected.Stings || !reflect.DeepEqual(received.Player, expected.Player) || received.Collapsed != expected.Collapsed || received.Commanded != expected.Commanded || !reflect.DeepEqual(received.Hive, expected.Hive) {
+				t.Errorf("Headless game %d differs from the played game. Expected: %+v. Received: %+v.", seed, expected, received)
+			}
+		}
+	}
+
+	game := createHeadlessGame(effects)
+	seed := uint64(0)
+	allocations := testing.AllocsPerRun(100, func() {
+		seed++
+		game.play(seed)
+	})
+
+	if allocations != 0 {
+		t.Errorf("Expected headless games not to allocate. Received: %.1f allocations per game", allocations)
+	}
+}
+
+// BenchmarkHeadlessGame measures how fast complete games are played without a client.
+func BenchmarkHeadlessGame(b *testing.B) {
+	game := createHeadlessGame(DefaultGameConfig())
+	turns := 0
+
+	b.ReportAllocs()
+
+	for index := range b.N {
+		state := game.play(uint64(index))
+		turns += int(state.Hits + state.Stings)
+	}
+
+	b.ReportMetric(float64(turns)/b.Elapsed().Seconds(), "turns/s")
+}
+
+// BenchmarkSimulate measures how fast batches of games are played across every CPU.
+func BenchmarkSimulate(b *testing.B) {
+	config := DefaultGameConfig()
+	turns := 0
+
+	b.ReportAllocs()
+
+	for index := range b.N {
+		results, err := Simulate(context.Background(), config, 1000, 8, uint64(index)*1000)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, result := range results {
+			turns += int(result.Hits + result.Stings)
+		}
+	}
+
+	b.ReportMetric(float64(turns)/b.Elapsed().Seconds(), "turns/s")
+}
+
+// BenchmarkServerGame measures how fast complete games are played through the protocol,
+// as a client plays them.
+func BenchmarkServerGame(b *testing.B) {
+	ctx := context.Background()
+	config := DefaultGameConfig()
+	turns := 0
+
+	b.ReportAllocs()
+
+	for index := range b.N {
+		communication := StartupServer(ctx, config, uint64(index), nil)
+
+		for finished := false; !finished; {
+			for _, receive := range []func(context.Context) (Event, error){communication.Hit, communication.WaitForCPU} {
+				for _, event := range mustEvents(b, ctx, communication, receive) {
+					turns++
+					finished = event.Finished
+				}
+
+				if finished {
+					break
+				}
+			}
+		}
+	}
+
+	b.ReportMetric(float64(turns)/b.Elapsed().Seconds(), "turns/s")
+}
@@ -2,24 +2,130 @@ package game
 
 // EventType represents the category of an event occurring in the game.
 // Events are generated by the server to inform the client about gameplay changes.
+//
+// PlayerPoisoned and BeePoisoned happen at the start of a round and are journal-only
+// kinds: they reach the client in the RoundStart of the round's first event and are
+// never an event's Type, unless the poison ends the game.
 type EventType uint
 
 const (
-	// PlayerAttack is sent after the player performed an attack.
-	PlayerAttack EventType = iota
+	// PlayerMissed is sent after the player's attack missed the hive.
+	PlayerMissed EventType = iota
 
-	// HiveAttack is sent after the hive performed an attack.
-	HiveAttack
+	// BeeHit is sent after the player hit a bee that survived.
+	BeeHit
 
-	// GameFinished is sent after the game reached a conclusion.
-	GameFinished
+	// BeeKilled is sent after the player killed a bee.
+	BeeKilled
+
+	// QueenKilled is sent after the player killed the hive's last queen.
+	QueenKilled
+
+	// BeeMissed is sent after a bee tried to sting the player and missed.
+	BeeMissed
+
+	// PlayerStung is sent after a bee stung the player and they survived.
+	PlayerStung
+
+	// PlayerKilled is sent after a bee, or a swarm of them, stung the player to death.
+	PlayerKilled
+
+	// HiveCollapsed is sent after every remaining bee died with their queen.
+	HiveCollapsed
+
+	// PlayerDefended is sent after the player braced themselves instead of attacking.
+	PlayerDefended
+
+	// ItemUsed is sent after the player used an item instead of attacking.
+	ItemUsed
+
+	// PlayerStunned is sent when the player's turn was skipped because they were stunned.
+	PlayerStunned
+
+	// PlayerPoisoned is recorded in the journal when poison hurt the player at the start
+	// of a round. It is sent in the RoundStart of the player's next event, and as an
+	// event of its own only when it killed them.
+	PlayerPoisoned
+
+	// BeePoisoned is recorded in the journal when poison hurt a bee at the start of a
+	// round. It is sent in the RoundStart of the player's next event, and as an event of
+	// its own only when it killed the whole hive.
+	BeePoisoned
+
+	// QueenFed is sent after a bee fed the hive's queen back some of her hit points
+	// instead of stinging.
+	QueenFed
+
+	// BeesSwarmed is sent after a swarm of bees stung the player at once and they survived.
+	BeesSwarmed
+
+	// QueenCommanded is sent after the queen issued a royal command that makes the hive's
+	// next sting certain to land.
+	QueenCommanded
+
+	// DronesHatched is sent when drones hatched from the queen's eggs at the start of a
+	// round. It reaches the client once the player has decided on their move, before the
+	// event describing it.
+	DronesHatched
 )
 
+// eventTypeNames holds the name of every event type, used when events are logged or exported.
+var eventTypeNames = map[EventType]string{
+	PlayerMissed:   "PlayerMissed",
+	BeeHit:         "BeeHit",
+	BeeKilled:      "BeeKilled",
+	QueenKilled:    "QueenKilled",
+	BeeMissed:      "BeeMissed",
+	PlayerStung:    "PlayerStung",
+	PlayerKilled:   "PlayerKilled",
+	HiveCollapsed:  "HiveCollapsed",
+	PlayerDefended: "PlayerDefended",
+	ItemUsed:       "ItemUsed",
+	PlayerStunned:  "PlayerStunned",
+	PlayerPoisoned: "PlayerPoisoned",
+	BeePoisoned:    "BeePoisoned",
+	QueenFed:       "QueenFed",
+	BeesSwarmed:    "BeesSwarmed",
+	QueenCommanded: "QueenCommanded",
+	DronesHatched:  "DronesHatched",
+}
+
+// String returns the name of the event type.
+func (eventType EventType) String() string {
+	return eventTypeNames[eventType]
+}
+
+// Outcome is the structured result of an attack, so clients can render what happened
+// without parsing the event's message. The target is the bee for the player's attacks
+// and the player for stings. Poison's target is whoever it hurt, and the queen is the
+// target of the bee that fed her. HiveCollapsed events carry no outcome.
+type Outcome struct {
+	BeeIndex        int     // Index in the hive of the bee that was attacked or that attacked.
+	BeeType         BeeType // Type of the bee that was attacked or that attacked.
+	Roll            uint    // The miss roll from 0 to 99; the attack missed if it was below the attacker's miss chance.
+	Damage          int     // Hit points the target lost.
+	HealthBefore    int     // Target's health before the attack.
+	HealthAfter     int     // Target's health after the attack.
+	Aimed           bool    // Whether the player aimed at the bee's type rather than the hive.
+	Blocked         int     // Hit points of a sting the player's defense blocked.
+	Critical        bool    // Whether the attack was a critical hit.
+	Item            Item    // The item the player used, or the item the bee dropped if ItemDropped.
+	ItemDropped     bool    // Whether the bee the player killed dropped an item.
+	Effect          Effect  // The status effect the sting inflicted on the player, or the hit on the bee, if EffectInflicted.
+	EffectInflicted bool    // Whether the sting or the hit inflicted a status effect.
+	Swarm           uint    // Number of bees that stung together in a swarm, led by the bee at BeeIndex.
+	SwarmStings     uint    // Number of the swarm's stings that landed.
+	Hatched         uint    // Number of drones that hatched, the first of them at BeeIndex.
+}
+
 // Event is a message sent from the server to the client, describing an action
 // or state transition in the game. It includes the type of event, a message
-// for context, and the current state of the game.
+// for context, the outcome of the attack and the current state of the game.
 type Event struct {
-	Type    EventType // The kind of event that occurred.
-	Message string    // A description of what just occurred.
-	State   GameState // The current game state after the event.
+	Type       EventType      // The kind of event that occurred.
+	Message    string         // A description of what just occurred.
+	Outcome    Outcome        // The structured result of the attack.
+	Finished   bool           // Whether the game ended with this event.
+	State      GameState      // A snapshot of the game state after the event, never changed once sent.
+	RoundStart []JournalEntry // What happened at the start of the round, e.g. poison, sent with the round's first event.
 }
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// resultRecord is how a GameResult is written to newline-delimited JSON.
+type resultRecord struct {
+	Seed         uint64          `json:"seed"`
+	Outcome      string          `json:"outcome"`
+	Rounds       uint            `json:"rounds"`
+	Hits         uint            `json:"hits"`
+	Stings       uint            `json:"stings"`
+	PlayerHealth int             `json:"player_health"`
+	Survivors    map[string]uint `json:"survivors"`
+	QueenDied    bool            `json:"queen_died"`
+}
+
+// outcome describes whether the player won or lost the game.
+func (result GameResult) outcome() string {
+	if result.Won {
+		return "won"
+	}
+
+	return "lost"
+}
+
+// ExportResults writes one row per game to w in the given format: "csv" for CSV with a
+// header row, or "ndjson" for newline-delimited JSON. Surviving bees are reported for
+// every bee type, named by their ruleset keys.
+func ExportResults(w io.Writer, results []GameResult, format string) error {
+	beeTypes := slices.Sorted(maps.Keys(beeDefinitions))
+
+	switch strings.ToLower(format) {
+	case "csv":
+		return exportCSV(w, results, beeTypes)
+	case "ndjson":
+		return exportNDJSON(w, results, beeTypes)
+	default:
+		return fmt.Errorf("unsupported export format %q, expected csv or ndjson", format)
+	}
+}
+
+// exportCSV writes the results as CSV, with a column of survivors for each bee type.
+func exportCSV(w io.Writer, results []GameResult, beeTypes []BeeType) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"seed", "outcome", "rounds", "hits", "stings", "player_health"}
+	for _, beeType := range beeTypes {
+		header = append(header, "surviving_"+beeType.key())
+	}
+
+	header = append(header, "queen_died")
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		row := []string{
+			strconv.FormatUint(result.Seed, 10),
+			result.outcome(),
+			strconv.FormatUint(uint64(result.Rounds), 10),
+			strconv.FormatUint(uint64(result.Hits), 10),
+			strconv.FormatUint(uint64(result.Stings), 10),
+			strconv.Itoa(result.PlayerHealth),
+		}
+
+		for _, beeType := range beeTypes {
+			row = append(row, strconv.FormatUint(uint64(result.Survivors[beeType]), 10))
+		}
+
+		row = append(row, strconv.FormatBool(result.QueenDied))
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// exportNDJSON writes the results as one JSON object per line.
+func exportNDJSON(w io.Writer, results []GameResult, beeTypes []BeeType) error {
+	encoder := json.NewEncoder(w)
+
+	for _, result := range results {
+		record := resultRecord{
+			Seed:         result.Seed,
+			Outcome:      result.outcome(),
+			Rounds:       result.Rounds,
+			Hits:         result.Hits,
+			Stings:       result.Stings,
+			PlayerHealth: result.PlayerHealth,
+			Survivors:    map[string]uint{},
+			QueenDied:    result.QueenDied,
+		}
+
+		for _, beeType := range beeTypes {
+			record.Survivors[beeType.key()] = result.Survivors[beeType]
+		}
+
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
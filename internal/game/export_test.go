@@ -0,0 +1,73 @@
+package game
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var exportResults = []GameResult{
+	{Seed: 7, Won: true, Rounds: 40, Hits: 35, Stings: 20, PlayerHealth: 12, Survivors: map[BeeType]uint{}, QueenDied: true},
+	{Seed: 8, Won: false, Rounds: 55, Hits: 48, Stings: 41, PlayerHealth: -3, Survivors: map[BeeType]uint{WorkerBee: 2, DroneBee: 9, QueenBee: 1}},
+}
+
+// TestExportCSV ensures every game is written as a CSV row under a header.
+func TestExportCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := ExportResults(&buffer, exportResults, "csv"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	rows, err := csv.NewReader(&buffer).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected valid CSV. Received: %s", err)
+	}
+
+	expected := [][]string{
+		{"seed", "outcome", "rounds", "hits", "stings", "player_health", "surviving_queen", "surviving_worker", "surviving_drone", "queen_died"},
+		{"7", "won", "40", "35", "20", "12", "0", "0", "0", "true"},
+		{"8", "lost", "55", "48", "41", "-3", "1", "2", "9", "false"},
+	}
+
+	if len(rows) != len(expected) {
+		t.Fatalf("Expected %d rows. Received: %d", len(expected), len(rows))
+	}
+
+	for index := range expected {
+		if strings.Join(rows[index], ",") != strings.Join(expected[index], ",") {
+			t.Errorf("Row %d. Expected: %v. Received: %v.", index, expected[index], rows[index])
+		}
+	}
+}
+
+// TestExportNDJSON ensures every game is written as a JSON object on its own line.
+func TestExportNDJSON(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := ExportResults(&buffer, exportResults, "ndjson"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines. Received: %d", len(lines))
+	}
+
+	var record resultRecord
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+		t.Fatalf("Expected valid JSON. Received: %s", err)
+	}
+
+	if record.Seed != 8 || record.Outcome != "lost" || record.PlayerHealth != -3 || record.Survivors["drone"] != 9 || record.Survivors["queen"] != 1 || record.QueenDied {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+
+	if !strings.Contains(lines[0], `"survivors":{"drone":0,"queen":0,"worker":0}`) {
+		t.Errorf("Expected every bee type to be reported. Received: %s", lines[0])
+	}
+
+	if err := ExportResults(&buffer, exportResults, "xml"); err == nil {
+		t.Error("Expected an error for an unsupported format.")
+	}
+}
@@ -0,0 +1,276 @@
+package game
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// fairnessSignificance is the p-value below which a fairness check reports that the
+// engine deviates from the odds it should be playing by.
+const fairnessSignificance = 0.001
+
+// Odds holds the chance of every outcome of the player's attack and the hive's sting
+// in a single round.
+type Odds struct {
+	Hit        map[BeeType]float64 // Chance the player's attack lands on a bee of each type.
+	PlayerMiss float64             // Chance the player's attack misses.
+	Sting      map[BeeType]float64 // Chance a bee of each type stings the player.
+	HiveMiss   float64             // Chance the bee that attacks the player misses.
+}
+
+// CalculateOdds returns the theoretical odds of the next round of a game in the given
+// state. Both sides pick the bee involved uniformly from the hive, so the odds of each
+// bee type follow its share of the hive and the miss chances of the attacker, given the
+// status effects it is under. While a royal command stands, only stunned bees can miss.
+func CalculateOdds(state GameState) Odds {
+	odds := Odds{
+		Hit:   map[BeeType]float64{},
+		Sting: map[BeeType]float64{},
+	}
+
+	if len(state.Hive) == 0 {
+		return odds
+	}
+
+	picked := 1 / float64(len(state.Hive))
+	playerMiss := missProbability(state.Player.Effects.missChance(state.Player.MissChance))
+
+	for _, bee := range state.Hive {
+		beeMiss := missProbability(stingMissChance(bee))
+		if state.Commanded && !bee.Effects.Has(Stunned) {
+			beeMiss = 0
+		}
+
+		odds.Hit[bee.Type] += picked * (1 - playerMiss)
+		odds.Sting[bee.Type] += picked * (1 - beeMiss)
+		odds.HiveMiss += picked * beeMiss
+	}
+
+	odds.PlayerMiss = playerMiss
+
+	return odds
+}
+
+// missProbability returns the chance an attack with the given miss chance misses.
+func missProbability(missChance uint) float64 {
+	return float64(min(missChance, 100)) / 100
+}
+
+// ChiSquareTest is the result of comparing how often outcomes were observed with how
+// often they were expected.
+type ChiSquareTest struct {
+	Statistic        float64
+	DegreesOfFreedom int
+	PValue           float64 // Chance of a deviation at least this large if the odds hold.
+	Deviates         bool    // Whether the deviation is too large to be down to chance.
+}
+
+// FairnessReport compares the odds the engine should be playing by with the odds it was
+// observed playing by.
+type FairnessReport struct {
+	Samples  int // Number of times each side's turn was played.
+	Expected Odds
+	Observed Odds
+	Attacks  ChiSquareTest
+	Stings   ChiSquareTest
+}
+
+// CheckFairness plays the player's and the hive's turn from the given state samples
+// times each, using the engine's own rules, and checks with a chi-square test that the bees
+// picked and the attacks that missed match CalculateOdds. The rolls are drawn from
+// NewSource(seed). The odds leave out the bees' abilities, so games in which the bees may
+// use them can't be checked.
+func CheckFairness(config GameConfig, state GameState, samples int, seed uint64) (FairnessReport, error) {
+	if samples <= 0 {
+		return FairnessReport{}, errors.New("the number of samples must be > 0")
+	}
+
+	if err := config.Validate(); err != nil {
+		return FairnessReport{}, err
+	}
+
+	if state.Player.Health <= 0 || len(state.Hive) == 0 {
+		return FairnessReport{}, errors.New("the game is already over")
+	}
+
+	if config.hasAbilities() {
+		return FairnessReport{}, errors.New("the fairness check can't account for bee abilities")
+	}
+
+	random := rand.New(NewSource(seed))
+	sample := state
+
+	// Items dropped while sampling mustn't end up in the caller's inventory.
+	inventory := maps.Clone(state.Player.Inventory)
+
+	attacks := map[BeeType]int{}
+	playerMisses := 0
+	stings := map[BeeType]int{}
+	hiveMisses := 0
+
+	// Every turn is played from a fresh copy of the state.
+	reset := func() *GameState {
+		sample.Player = state.Player
+		sample.Player.Inventory = inventory
+		sample.Commanded = state.Commanded
+		sample.Hive = append(sample.Hive[:0], state.Hive...)
+
+		return &sample
+	}
+
+	sample.Hive = nil
+
+	for range samples {
+		switch eventType, outcome := attack(reset(), config, random); eventType {
+		case PlayerMissed:
+			playerMisses++
+		default:
+			attacks[outcome.BeeType]++
+		}
+
+		switch eventType, outcome := sting(reset(), config, random); eventType {
+		case BeeMissed:
+			hiveMisses++
+		default:
+			stings[outcome.BeeType]++
+		}
+	}
+
+	report := FairnessReport{
+		Samples:  samples,
+		Expected: CalculateOdds(state),
+		Observed: Odds{
+			Hit:        frequencies(attacks, samples),
+			PlayerMiss: float64(playerMisses) / float64(samples),
+			Sting:      frequencies(stings, samples),
+			HiveMiss:   float64(hiveMisses) / float64(samples),
+		},
+	}
+
+	report.Attacks = chiSquare(report.Expected.Hit, report.Expected.PlayerMiss, attacks, playerMisses, samples)
+	report.Stings = chiSquare(report.Expected.Sting, report.Expected.HiveMiss, stings, hiveMisses, samples)
+
+	return report, nil
+}
+
+// frequencies turns the number of times each bee type was observed into its share of the samples.
+func frequencies(counts map[BeeType]int, samples int) map[BeeType]float64 {
+	shares := map[BeeType]float64{}
+	for beeType, count := range counts {
+		shares[beeType] = float64(count) / float64(samples)
+	}
+
+	return shares
+}
+
+// chiSquare tests the observed outcomes of a turn against the expected odds. An outcome
+// that was observed but should never happen fails the test outright.
+func chiSquare(expected map[BeeType]float64, expectedMiss float64, observed map[BeeType]int, observedMisses, samples int) ChiSquareTest {
+	var test ChiSquareTest
+	categories := 0
+
+	addCategory := func(probability float64, count int) {
+		if probability <= 0 {
+			if count > 0 {
+				test.Statistic = math.Inf(1)
+			}
+
+			return
+		}
+
+		want := probability * float64(samples)
+		test.Statistic += (float64(count) - want) * (float64(count) - want) / want
+		categories++
+	}
+
+	beeTypes := slices.Collect(maps.Keys(expected))
+	for beeType := range observed {
+		if _, ok := expected[beeType]; !ok {
+			beeTypes = append(beeTypes, beeType)
+		}
+	}
+
+	slices.Sort(beeTypes)
+
+	for _, beeType := range beeTypes {
+		addCategory(expected[beeType], observed[beeType])
+	}
+
+	addCategory(expectedMiss, observedMisses)
+
+	test.DegreesOfFreedom = max(categories-1, 0)
+	test.PValue = chiSquarePValue(test.Statistic, test.DegreesOfFreedom)
+	test.Deviates = test.PValue < fairnessSignificance
+
+	return test
+}
+
+// chiSquarePValue returns the chance of a chi-square statistic at least as large as
+// statistic with the given degrees of freedom.
+func chiSquarePValue(statistic float64, degreesOfFreedom int) float64 {
+	if math.IsInf(statistic, 1) {
+		return 0
+	}
+
+	if degreesOfFreedom == 0 || statistic <= 0 {
+		return 1
+	}
+
+	return upperIncompleteGamma(float64(degreesOfFreedom)/2, statistic/2)
+}
+
+// upperIncompleteGamma returns the regularized upper incomplete gamma function Q(a, x),
+// using its series below a+1 and its continued fraction above.
+func upperIncompleteGamma(a, x float64) float64 {
+	const epsilon = 1e-15
+	const tiny = 1e-300
+
+	logGamma, _ := math.Lgamma(a)
+	scale := math.Exp(a*math.Log(x) - x - logGamma)
+
+	if x < a+1 {
+		term := 1 / a
+		sum := term
+
+		for n := 1; n < 1000 && math.Abs(term) > math.Abs(sum)*epsilon; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+		}
+
+		return max(1-sum*scale, 0)
+	}
+
+	// Modified Lentz's method.
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	fraction := d
+
+	for n := 1; n < 1000; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+
+		d = 1 / d
+		delta := d * c
+		fraction *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+
+	return fraction * scale
+}
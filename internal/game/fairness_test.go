@@ -0,0 +1,165 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCalculateOdds ensures the odds of each bee type follow its share of the hive and
+// the attacker's miss chance.
+func TestCalculateOdds(t *testing.T) {
+	state := GameState{
+		Player: Player{Health: 100, MissChance: 20},
+		Hive: []Bee{
+			{Type: QueenBee, Health: 100, MissChance: 0},
+			{Type: WorkerBee, Health: 75, MissChance: 50},
+			{Type: WorkerBee, Health: 75, MissChance: 50},
+			{Type: DroneBee, Health: 60, MissChance: 100},
+		},
+	}
+
+	odds := CalculateOdds(state)
+
+	expected := map[string]float64{
+		"hit queen":    0.2,
+		"hit worker":   0.4,
+		"hit drone":    0.2,
+		"player miss":  0.2,
+		"sting queen":  0.25,
+		"sting worker": 0.25,
+		"sting drone":  0,
+		"hive miss":    0.5,
+	}
+
+	received := map[string]float64{
+		"hit queen":    odds.Hit[QueenBee],
+		"hit worker":   odds.Hit[WorkerBee],
+		"hit drone":    odds.Hit[DroneBee],
+		"player miss":  odds.PlayerMiss,
+		"sting queen":  odds.Sting[QueenBee],
+		"sting worker": odds.Sting[WorkerBee],
+		"sting drone":  odds.Sting[DroneBee],
+		"hive miss":    odds.HiveMiss,
+	}
+
+	for name, chance := range expected {
+		if math.Abs(received[name]-chance) > 1e-9 {
+			t.Errorf("Expected the %s chance to be %.2f. Received: %.4f", name, chance, received[name])
+		}
+	}
+
+	// Under a royal command, only the stunned worker can still miss.
+	state.Commanded = true
+	state.Hive[1].Effects.inflict(Stunned, 1)
+
+	if odds = CalculateOdds(state); math.Abs(odds.HiveMiss-0.25) > 1e-9 || math.Abs(odds.Sting[WorkerBee]-0.25) > 1e-9 || odds.Sting[DroneBee] != 0.25 {
+		t.Errorf("Expected the command to make every bee but the stunned one sting. Received: %+v", odds)
+	}
+
+	if empty := CalculateOdds(GameState{}); len(empty.Hit) != 0 || empty.PlayerMiss != 0 {
+		t.Errorf("Expected no odds for an empty hive. Received: %+v", empty)
+	}
+}
+
+// TestCheckFairness ensures the engine plays by the odds it reports, both for the
+// standard game and for a hive with extreme miss chances.
+func TestCheckFairness(t *testing.T) {
+	config := DefaultGameConfig()
+
+	extreme := NewGameState(config, 1)
+	extreme.Player.MissChance = 0
+	for index := range extreme.Hive {
+		extreme.Hive[index].MissChance = 100 * uint(index%2)
+	}
+
+	for _, state := range []GameState{NewGameState(config, 1), extreme} {
+		report, err := CheckFairness(config, state, 20000, 42)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if report.Attacks.Deviates || report.Stings.Deviates {
+			t.Errorf("Expected the engine to be fair. Report: %+v", report)
+		}
+
+		if report.Attacks.DegreesOfFreedom < 1 || report.Stings.DegreesOfFreedom < 1 {
+			t.Errorf("Expected every outcome to be tested. Report: %+v", report)
+		}
+
+		for beeType, chance := range report.Expected.Hit {
+			if math.Abs(report.Observed.Hit[beeType]-chance) > 0.02 {
+				t.Errorf("Observed hit chance for %s is far from %.3f: %.3f", beeType, chance, report.Observed.Hit[beeType])
+			}
+		}
+	}
+
+	if _, err := CheckFairness(config, NewGameState(config, 1), 0, 1); err == nil {
+		t.Error("Expected an error without any samples.")
+	}
+
+	if _, err := CheckFairness(config, GameState{Player: Player{Health: 100}}, 10, 1); err == nil {
+		t.Error("Expected an error for a game that's already over.")
+	}
+
+	abilities, _ := config.WithSetting("drone.ability_chance", 10)
+	if _, err := CheckFairness(abilities, NewGameState(abilities, 1), 10, 1); err == nil {
+		t.Error("Expected an error for bees that may use their abilities.")
+	}
+
+	// A royal command standing makes every sting land.
+	commanded := NewGameState(config, 1)
+	commanded.Commanded = true
+
+	report, err := CheckFairness(config, commanded, 20000, 42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if report.Stings.Deviates || report.Expected.HiveMiss != 0 || report.Observed.HiveMiss != 0 {
+		t.Errorf("Expected every sting to land while the command stands. Report: %+v", report)
+	}
+}
+
+// TestChiSquare ensures deviations from the expected odds are flagged.
+func TestChiSquare(t *testing.T) {
+	expected := map[BeeType]float64{QueenBee: 0.25, WorkerBee: 0.25}
+
+	fair := chiSquare(expected, 0.5, map[BeeType]int{QueenBee: 251, WorkerBee: 249}, 500, 1000)
+	if fair.Deviates || fair.DegreesOfFreedom != 2 || fair.PValue < 0.9 {
+		t.Errorf("Expected a fair result. Received: %+v", fair)
+	}
+
+	biased := chiSquare(expected, 0.5, map[BeeType]int{QueenBee: 400, WorkerBee: 100}, 500, 1000)
+	if !biased.Deviates {
+		t.Errorf("Expected a biased result to be flagged. Received: %+v", biased)
+	}
+
+	impossible := chiSquare(expected, 0.5, map[BeeType]int{QueenBee: 250, WorkerBee: 249, DroneBee: 1}, 500, 1000)
+	if !impossible.Deviates || impossible.PValue != 0 {
+		t.Errorf("Expected an impossible outcome to be flagged. Received: %+v", impossible)
+	}
+}
+
+// TestChiSquarePValue checks p-values against known values of the chi-square distribution.
+func TestChiSquarePValue(t *testing.T) {
+	scenarios := []struct {
+		statistic        float64
+		degreesOfFreedom int
+		expected         float64
+	}{
+		{3.841, 1, 0.05},
+		{6.635, 1, 0.01},
+		{4, 2, math.Exp(-2)},
+		{11.345, 3, 0.01},
+		{1.064, 4, 0.9},
+		{0, 3, 1},
+		{math.Inf(1), 3, 0},
+	}
+
+	for _, scenario := range scenarios {
+		received := chiSquarePValue(scenario.statistic, scenario.degreesOfFreedom)
+		if math.Abs(received-scenario.expected) > 1e-3 {
+			t.Errorf("Expected p-value of %.3f with %d degrees of freedom to be %.4f. Received: %.4f", scenario.statistic, scenario.degreesOfFreedom, scenario.expected, received)
+		}
+	}
+}
@@ -11,123 +11,245 @@ const (
 	DroneBee
 )
 
+// Ability represents a special action a bee can take on the hive's turn instead of
+// stinging.
+type Ability uint
+
+const (
+	// Feed heals the hive's queen by feedHealing hit points.
+	Feed Ability = iota
+
+	// Swarm has up to swarmSize bees of the same type sting the player at once, for
+	// swarmDamage each.
+	Swarm
+
+	// RoyalCommand makes the hive's next sting certain to land.
+	RoyalCommand
+)
+
+// beeDefinition describes everything that sets one type of bee apart from another:
+// how it is named, its default stats, its ability and the messages shown when it is
+// hit or when it stings the player.
+type beeDefinition struct {
+	BeeStats
+
+	name string
+	key  string // Identifies the bee type in ruleset files.
+
+	hitMessage        string // Formatted with the damage taken and the health left.
+	critHitMessage    string // Formatted with the damage taken and the health left.
+	killMessage       string
+	stingMessage      string // Formatted with the player's health left.
+	critStingMessage  string // Formatted with the damage dealt and the player's health left.
+	fatalStingMessage string
+
+	stingEffect       Effect // Inflicted on the player by the bee's stings, by the type's effect chance.
+	stingEffectRounds uint
+
+	hitEffect       Effect // Inflicted on the bee by the player's hits, by the player's effect chance.
+	hitEffectRounds uint
+
+	ability Ability // Used instead of stinging, by the type's ability chance.
+}
+
+// beeDefinitions is the registry of every bee type in the game. Adding a new type of
+// bee only requires a new BeeType constant and an entry here.
+var beeDefinitions = map[BeeType]beeDefinition{
+	QueenBee: {
+		name: "Queen bee",
+		key:  "queen",
+		BeeStats: BeeStats{
+			Health:        100,
+			DamageTaken:   10,
+			DamageDealt:   10,
+			DamageSpread:  0,
+			MissChance:    10,
+			CritChance:    0,
+			AimMissChance: 60,
+			DropChance:    50,
+			EffectChance:  0,
+			AbilityChance: 0,
+			Count:         1,
+		},
+		hitMessage:        "Direct Hit! Queen took %d hit points. %d HP left.",
+		critHitMessage:    "Critical hit! %d damage to the Queen bee. %d HP left.",
+		killMessage:       "You killed the Queen bee.",
+		stingMessage:      "Sting! You just got stun by the Queen bee. You have %d HP left.",
+		critStingMessage:  "Critical sting! The Queen bee stung you for %d damage. You have %d HP left.",
+		fatalStingMessage: "The Queen bee just killed you!",
+		stingEffect:       Stunned,
+		stingEffectRounds: 2,
+		hitEffect:         Poisoned,
+		hitEffectRounds:   3,
+		ability:           RoyalCommand,
+	},
+	WorkerBee: {
+		name: "worker bee",
+		key:  "worker",
+		BeeStats: BeeStats{
+			Health:        75,
+			DamageTaken:   25,
+			DamageDealt:   5,
+			DamageSpread:  0,
+			MissChance:    15,
+			CritChance:    0,
+			AimMissChance: 25,
+			DropChance:    20,
+			EffectChance:  0,
+			AbilityChance: 0,
+			Count:         5,
+		},
+		hitMessage:        "Direct Hit! Worker took %d hit points. %d HP left.",
+		critHitMessage:    "Critical hit! %d damage to a worker bee. %d HP left.",
+		killMessage:       "You killed a worker bee.",
+		stingMessage:      "Sting! You just got stun by a worker bee. You have %d HP left.",
+		critStingMessage:  "Critical sting! A worker bee stung you for %d damage. You have %d HP left.",
+		fatalStingMessage: "A worker bee just killed you!",
+		stingEffect:       Poisoned,
+		stingEffectRounds: 4,
+		hitEffect:         Enraged,
+		hitEffectRounds:   2,
+		ability:           Feed,
+	},
+	DroneBee: {
+		name: "drone bee",
+		key:  "drone",
+		BeeStats: BeeStats{
+			Health:        60,
+			DamageTaken:   30,
+			DamageDealt:   1,
+			DamageSpread:  0,
+			MissChance:    20,
+			CritChance:    0,
+			AimMissChance: 15,
+			DropChance:    10,
+			EffectChance:  0,
+			AbilityChance: 0,
+			Count:         25,
+		},
+		hitMessage:        "Direct Hit! Drone took %d hit points. %d HP left.",
+		critHitMessage:    "Critical hit! %d damage to a drone bee. %d HP left.",
+		killMessage:       "You killed a drone bee.",
+		stingMessage:      "Sting! You just got stun by a drone bee. You have %d HP left.",
+		critStingMessage:  "Critical sting! A drone bee stung you for %d damage. You have %d HP left.",
+		fatalStingMessage: "A drone bee just killed you!",
+		stingEffect:       Enraged,
+		stingEffectRounds: 3,
+		hitEffect:         Stunned,
+		hitEffectRounds:   2,
+		ability:           Swarm,
+	},
+}
+
 // String returns the string representation of a BeeType.
 func (beeType BeeType) String() string {
-	switch beeType {
-	case QueenBee:
-		return "Queen bee"
-	case WorkerBee:
-		return "worker bee"
-	case DroneBee:
-		return "drone bee"
-	default:
-		return ""
-	}
+	return beeDefinitions[beeType].name
 }
 
-const (
-	damageAgainstQueen  = 10
-	damageAgainstWorker = 25
-	damageAgainstDrone  = 30
-)
+// key returns the name used for the bee type in ruleset files, or an empty string
+// for unknown types.
+func (beeType BeeType) key() string {
+	return beeDefinitions[beeType].key
+}
+
+// ParseBeeType returns the bee type named by key the way it appears in ruleset files,
+// e.g. "worker", or false if no bee type goes by that name.
+func ParseBeeType(key string) (BeeType, bool) {
+	for beeType, definition := range beeDefinitions {
+		if definition.key == key {
+			return beeType, true
+		}
+	}
+
+	return 0, false
+}
 
 // Bee represents an individual bee in the hive, including its type,
-// remaining health, and chance to miss an attack.
+// remaining health, chance to miss an attack and the status effects it is under.
 type Bee struct {
 	Type       BeeType
 	Health     int
 	MissChance uint
+	Effects    Effects
 }
 
-// takeDamage applies damage to the bee based on its type.
+// takeDamage deducts the given damage from the bee's health.
 // It returns true if the bee's health drops to zero or below (i.e., the bee is dead).
-func (bee *Bee) takeDamage() bool {
-	damage := 0
-
-	switch bee.Type {
-	case QueenBee:
-		damage = damageAgainstQueen
-	case WorkerBee:
-		damage = damageAgainstWorker
-	case DroneBee:
-		damage = damageAgainstDrone
-	}
-
+func (bee *Bee) takeDamage(damage int) bool {
 	bee.Health -= damage
 
 	return bee.Health <= 0
 }
 
 // generateHitMessage returns a descriptive string about the result
-// of a hit on the bee, including whether it was fatal.
-func (bee *Bee) generateHitMessage(died bool) string {
-	msg := ""
+// of a hit on the bee, including whether it was fatal or a critical hit.
+func (bee *Bee) generateHitMessage(damage int, died, critical bool) string {
+	definition, ok := beeDefinitions[bee.Type]
+	if !ok {
+		return ""
+	}
 
 	switch {
-	case bee.Type == QueenBee && died:
-		msg = "You killed the Queen bee."
-	case bee.Type == QueenBee && !died:
-		msg = fmt.Sprintf("Direct Hit! Queen took %d hit points. %d HP left.", damageAgainstQueen, bee.Health)
-	case bee.Type == WorkerBee && died:
-		msg = "You killed a worker bee."
-	case bee.Type == WorkerBee && !died:
-		msg = fmt.Sprintf("Direct Hit! Worker took %d hit points. %d HP left.", damageAgainstWorker, bee.Health)
-	case bee.Type == DroneBee && died:
-		msg = "You killed a drone bee."
-	case bee.Type == DroneBee && !died:
-		msg = fmt.Sprintf("Direct Hit! Drone took %d hit points. %d HP left.", damageAgainstDrone, bee.Health)
+	case died && critical:
+		return "Critical hit! " + definition.killMessage
+	case died:
+		return definition.killMessage
+	case critical:
+		return fmt.Sprintf(definition.critHitMessage, damage, bee.Health)
 	}
 
-	return msg
+	return fmt.Sprintf(definition.hitMessage, damage, bee.Health)
 }
 
-// createBees returns a slice of Bee instances of the given type and quantity.
-// Optionally, a custom miss chance can be provided.
-func createBees(beeType BeeType, num uint, missChance ...uint) []Bee {
-	var health int
-	var miss uint
-
-	switch beeType {
-	case QueenBee:
-		health = 100
-		miss = 10
-	case WorkerBee:
-		health = 75
-		miss = 15
-	case DroneBee:
-		health = 60
-		miss = 20
-	default:
+// createBees returns a slice of Bee instances of the given type, built from its stats.
+// Unknown bee types yield nil.
+func createBees(beeType BeeType, stats BeeStats) []Bee {
+	if _, ok := beeDefinitions[beeType]; !ok {
 		return nil
 	}
 
-	if len(missChance) > 0 {
-		miss = missChance[0]
-	}
-
-	bees := make([]Bee, num)
+	bees := make([]Bee, stats.Count)
 	for index := range bees {
 		bees[index] = Bee{
 			Type:       beeType,
-			Health:     health,
-			MissChance: miss,
+			Health:     stats.Health,
+			MissChance: stats.MissChance,
 		}
 	}
 
 	return bees
 }
 
-// createHive returns a full hive composed of queens, workers, and drones.
-// It constructs the hive using the given number of each type of bee.
-func createHive(numQueens, numWorkers, numDrones uint) []Bee {
-	queens := createBees(QueenBee, numQueens)
-	workers := createBees(WorkerBee, numWorkers)
-	drones := createBees(DroneBee, numDrones)
+// createHive returns a full hive built from the config's bee stats, ordered by bee type.
+func createHive(config GameConfig) []Bee {
+	bees := []Bee{}
 
-	bees := make([]Bee, 0, numQueens+numWorkers+numDrones)
-	bees = append(bees, queens...)
-	bees = append(bees, workers...)
-	bees = append(bees, drones...)
+	for _, beeType := range config.beeTypes() {
+		bees = append(bees, createBees(beeType, config.Bees[beeType])...)
+	}
 
 	return bees
 }
+
+// fillHive refills the hive with every bee described by the config, reusing the memory of
+// the given hive. The bee types must be given in ascending order, as createHive orders them.
+func fillHive(hive []Bee, config GameConfig, beeTypes []BeeType) []Bee {
+	hive = hive[:0]
+
+	for _, beeType := range beeTypes {
+		if _, ok := beeDefinitions[beeType]; !ok {
+			continue
+		}
+
+		stats := config.Bees[beeType]
+		for range stats.Count {
+			hive = append(hive, Bee{
+				Type:       beeType,
+				Health:     stats.Health,
+				MissChance: stats.MissChance,
+			})
+		}
+	}
+
+	return hive
+}
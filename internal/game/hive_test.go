@@ -2,6 +2,7 @@ package game
 
 import (
 	"fmt"
+	"slices"
 	"testing"
 )
 
@@ -37,12 +38,12 @@ func TestHiveTakeDamage(t *testing.T) {
 		expectedHealth int
 		shouldHaveDied bool
 	}{
-		{QueenBee, damageAgainstQueen + 5, 5, false},
-		{QueenBee, damageAgainstQueen - 5, -5, true},
-		{WorkerBee, damageAgainstWorker + 5, 5, false},
-		{WorkerBee, damageAgainstWorker - 5, -5, true},
-		{DroneBee, damageAgainstDrone + 5, 5, false},
-		{DroneBee, damageAgainstDrone - 5, -5, true},
+		{QueenBee, beeDefinitions[QueenBee].DamageTaken + 5, 5, false},
+		{QueenBee, beeDefinitions[QueenBee].DamageTaken - 5, -5, true},
+		{WorkerBee, beeDefinitions[WorkerBee].DamageTaken + 5, 5, false},
+		{WorkerBee, beeDefinitions[WorkerBee].DamageTaken - 5, -5, true},
+		{DroneBee, beeDefinitions[DroneBee].DamageTaken + 5, 5, false},
+		{DroneBee, beeDefinitions[DroneBee].DamageTaken - 5, -5, true},
 	}
 
 	for _, scenario := range scenarios {
@@ -51,7 +52,7 @@ func TestHiveTakeDamage(t *testing.T) {
 			Health: scenario.beeHealth,
 		}
 
-		died := bee.takeDamage()
+		died := bee.takeDamage(beeDefinitions[scenario.beeType].DamageTaken)
 
 		if bee.Health != scenario.expectedHealth {
 			t.Errorf("Bee's health is different from what was expected after an attack. Expected health: %d. Bee's health: %d. Bee type: %s\n", bee.Health, scenario.expectedHealth, bee.Type)
@@ -95,54 +96,55 @@ func TestHiveGenerateHitMessage(t *testing.T) {
 		died bool
 	}{
 		{queenBee, "You killed the Queen bee.", true},
-		{queenBee, fmt.Sprintf("Direct Hit! Queen took %d hit points. %d HP left.", damageAgainstQueen, queenBee.Health), false},
+		{queenBee, fmt.Sprintf("Direct Hit! Queen took %d hit points. %d HP left.", beeDefinitions[QueenBee].DamageTaken, queenBee.Health), false},
 		{workerBee, "You killed a worker bee.", true},
-		{workerBee, fmt.Sprintf("Direct Hit! Worker took %d hit points. %d HP left.", damageAgainstWorker, workerBee.Health), false},
+		{workerBee, fmt.Sprintf("Direct Hit! Worker took %d hit points. %d HP left.", beeDefinitions[WorkerBee].DamageTaken, workerBee.Health), false},
 		{droneBee, "You killed a drone bee.", true},
-		{droneBee, fmt.Sprintf("Direct Hit! Drone took %d hit points. %d HP left.", damageAgainstDrone, droneBee.Health), false},
+		{droneBee, fmt.Sprintf("Direct Hit! Drone took %d hit points. %d HP left.", beeDefinitions[DroneBee].DamageTaken, droneBee.Health), false},
 		{unknownBee, "", true},
 		{unknownBee, "", false},
 	}
 
 	for _, scenario := range scenarios {
 		testBee := scenario.bee
-		msg := (&testBee).generateHitMessage(scenario.died)
+		msg := (&testBee).generateHitMessage(beeDefinitions[testBee.Type].DamageTaken, scenario.died, false)
 
 		if msg != scenario.msg {
 			t.Errorf("Generated hit message does not match expected hit message.\n \tExpected: \"%s\"\n\tGot: \"%s\"\n", scenario.msg, msg)
 		}
 	}
+
+	droneBee.Health = 15
+
+	if msg := droneBee.generateHitMessage(45, false, true); msg != "Critical hit! 45 damage to a drone bee. 15 HP left." {
+		t.Errorf("Unexpected message for a critical hit: %q", msg)
+	}
+
+	if msg := queenBee.generateHitMessage(15, true, true); msg != "Critical hit! You killed the Queen bee." {
+		t.Errorf("Unexpected message for a fatal critical hit: %q", msg)
+	}
 }
 
 // TestCreateBees verifies that createBees correctly initializes a slice of bees with
-// expected type, health, and miss chance. It also ensures unknown types return nil.
+// the type, health, and miss chance given by the stats. It also ensures unknown types return nil.
 func TestCreateBees(t *testing.T) {
 	scenarios := []struct {
-		beeType            BeeType
-		count              uint
-		withMissChance     bool
-		expectedHealth     int
-		expectedMissChance uint
+		beeType BeeType
+		stats   BeeStats
 	}{
-		{QueenBee, 5, true, 100, 35},
-		{QueenBee, 5, false, 100, 10},
-		{WorkerBee, 5, true, 75, 35},
-		{WorkerBee, 5, false, 75, 15},
-		{DroneBee, 5, true, 60, 35},
-		{DroneBee, 5, false, 60, 20},
+		{QueenBee, beeDefinitions[QueenBee].BeeStats},
+		{QueenBee, BeeStats{Health: 150, MissChance: 35, Count: 5}},
+		{WorkerBee, beeDefinitions[WorkerBee].BeeStats},
+		{WorkerBee, BeeStats{Health: 50, MissChance: 35, Count: 5}},
+		{DroneBee, beeDefinitions[DroneBee].BeeStats},
+		{DroneBee, BeeStats{Health: 20, MissChance: 35, Count: 5}},
 	}
 
 	for _, scenario := range scenarios {
-		var bees []Bee
+		bees := createBees(scenario.beeType, scenario.stats)
 
-		if scenario.withMissChance {
-			bees = createBees(scenario.beeType, scenario.count, scenario.expectedMissChance)
-		} else {
-			bees = createBees(scenario.beeType, scenario.count)
-		}
-
-		if len(bees) != int(scenario.count) {
-			t.Errorf("createBees gave incorrect number of bees. Requested: %d. Received: %d\n", scenario.count, len(bees))
+		if len(bees) != int(scenario.stats.Count) {
+			t.Errorf("createBees gave incorrect number of bees. Requested: %d. Received: %d\n", scenario.stats.Count, len(bees))
 		}
 
 		for _, bee := range bees {
@@ -150,57 +152,76 @@ func TestCreateBees(t *testing.T) {
 				t.Errorf("createBees gave incorrect bee type. Requested bee type: %s. Received bee type: %s\n", scenario.beeType, bee.Type)
 			}
 
-			if bee.Health != scenario.expectedHealth {
-				t.Errorf("createBees gave bee with incorrect health. Expected health: %d. Received health: %d\n", scenario.expectedHealth, bee.Health)
+			if bee.Health != scenario.stats.Health {
+				t.Errorf("createBees gave bee with incorrect health. Expected health: %d. Received health: %d\n", scenario.stats.Health, bee.Health)
 			}
 
-			if bee.MissChance != scenario.expectedMissChance {
-				t.Errorf("createBees gave bee with incorrect miss chance. Expected miss chance: %d. Received miss chance: %d\n", scenario.expectedMissChance, bee.MissChance)
+			if bee.MissChance != scenario.stats.MissChance {
+				t.Errorf("createBees gave bee with incorrect miss chance. Expected miss chance: %d. Received miss chance: %d\n", scenario.stats.MissChance, bee.MissChance)
 			}
 		}
 	}
 
 	// Test invalid bee type.
-	bees := createBees(BeeType(5), 5)
+	bees := createBees(BeeType(5), BeeStats{Count: 5})
 	if bees != nil {
 		t.Error("createBees returned a slice when nil was expected.")
 	}
 }
 
-// TestCreateHive validates that the createHive function returns a hive with the correct
-// composition of queens, workers, and drones.
+// TestCreateHive validates that the createHive function returns a hive with the
+// configured composition of queens, workers, and drones, ordered by bee type.
 func TestCreateHive(t *testing.T) {
-	numQueens := 5
-	numWorkers := 10
-	numDrones := 15
-
-	hive := createHive(uint(numQueens), uint(numWorkers), uint(numDrones))
-
-	if len(hive) != numQueens+numWorkers+numDrones {
-		receivedQueens := 0
-		receivedWorkers := 0
-		receivedDrones := 0
-
-		for _, bee := range hive {
-			if bee.Type == QueenBee {
-				receivedQueens++
-			} else if bee.Type == WorkerBee {
-				receivedWorkers++
-			} else if bee.Type == DroneBee {
-				receivedDrones++
-			}
+	config := DefaultGameConfig()
+	config.Bees[QueenBee] = BeeStats{Health: 100, Count: 5}
+	config.Bees[WorkerBee] = BeeStats{Health: 75, Count: 10}
+	config.Bees[DroneBee] = BeeStats{Health: 60, Count: 15}
+
+	hive := createHive(config)
+
+	receivedCounts := map[BeeType]uint{}
+	for _, bee := range hive {
+		receivedCounts[bee.Type]++
+	}
+
+	for beeType, stats := range config.Bees {
+		if receivedCounts[beeType] != stats.Count {
+			t.Errorf("createHive gave incorrect number of %ss. Expected: %d. Received: %d\n", beeType, stats.Count, receivedCounts[beeType])
 		}
+	}
+
+	if !slices.IsSortedFunc(hive, func(a, b Bee) int { return int(a.Type) - int(b.Type) }) {
+		t.Error("createHive did not order the hive by bee type.")
+	}
+}
 
-		if receivedQueens != numQueens {
-			t.Errorf("createHive gave incorrect number of queens. Requested: %d. Received: %d\n", numQueens, receivedQueens)
+// TestBeeDefinitions guards the registry against incomplete entries, since every bee
+// type relies on its definition for names, stats, and messages.
+func TestBeeDefinitions(t *testing.T) {
+	for beeType, definition := range beeDefinitions {
+		if definition.name == "" {
+			t.Errorf("Bee type %d has no name.", beeType)
 		}
 
-		if receivedWorkers != numWorkers {
-			t.Errorf("createHive gave incorrect number of workers. Requested: %d. Received: %d\n", numWorkers, receivedWorkers)
+		if definition.Health <= 0 || definition.DamageTaken <= 0 || definition.DamageDealt <= 0 || definition.Count == 0 {
+			t.Errorf("Bee type %s has non-positive stats: %+v", beeType, definition)
 		}
 
-		if receivedDrones != numDrones {
-			t.Errorf("createHive gave incorrect number of drones. Requested: %d. Received: %d\n", numDrones, receivedDrones)
+		if definition.hitMessage == "" || definition.killMessage == "" || definition.stingMessage == "" || definition.fatalStingMessage == "" {
+			t.Errorf("Bee type %s is missing one or more messages.", beeType)
 		}
 	}
 }
+
+// TestParseBeeType ensures bee types are found by the key they use in ruleset files.
+func TestParseBeeType(t *testing.T) {
+	for beeType, definition := range beeDefinitions {
+		if parsed, ok := ParseBeeType(definition.key); !ok || parsed != beeType {
+			t.Errorf("Expected %q to be the %s. Received: %s, %t", definition.key, beeType, parsed, ok)
+		}
+	}
+
+	if _, ok := ParseBeeType("wasp"); ok {
+		t.Errorf("Expected \"wasp\" not to be a bee type.")
+	}
+}
@@ -0,0 +1,113 @@
+package game
+
+import "fmt"
+
+// Item represents a kind of consumable the player can carry and use on their turn.
+type Item uint
+
+const (
+	HealingSalve Item = iota
+	SmokeCanister
+	Antihistamine
+)
+
+const (
+	// salveHealing is the hit points a healing salve restores, up to the player's
+	// starting health.
+	salveHealing = 25
+
+	// smokeRounds is the number of rounds a smoke canister leaves the hive smoked for,
+	// counting the round it was used in.
+	smokeRounds = 3
+)
+
+// itemDefinition describes everything that sets one kind of item apart from another.
+type itemDefinition struct {
+	name        string
+	key         string // Identifies the item in ruleset files and in the use command.
+	description string
+	useMessage  string // Formatted with the hit points restored and the health left for healing items.
+}
+
+// itemDefinitions is the registry of every item in the game. The items are numbered from
+// zero without gaps, so a dropped item can be picked by its number.
+var itemDefinitions = map[Item]itemDefinition{
+	HealingSalve: {
+		name:        "healing salve",
+		key:         "salve",
+		description: "Restores 25 HP",
+		useMessage:  "You apply a healing salve and recover %d HP. You have %d HP left.",
+	},
+	SmokeCanister: {
+		name:        "smoke canister",
+		key:         "smoke",
+		description: "Smokes the hive, making the bees miss more often for 3 rounds",
+		useMessage:  "You light a smoke canister. The bees will struggle to find you for 3 rounds.",
+	},
+	Antihistamine: {
+		name:        "antihistamine",
+		key:         "antihistamine",
+		description: "Cures poison and takes the sting out of the next sting that lands",
+		useMessage:  "You take an antihistamine. Any poison is cured and the next sting that lands won't hurt.",
+	},
+}
+
+// String returns the string representation of an Item.
+func (item Item) String() string {
+	return itemDefinitions[item].name
+}
+
+// Key returns the name used for the item in ruleset files and in the use command, or an
+// empty string for unknown items.
+func (item Item) Key() string {
+	return itemDefinitions[item].key
+}
+
+// Description returns a short description of what the item does.
+func (item Item) Description() string {
+	return itemDefinitions[item].description
+}
+
+// generateUseMessage returns a descriptive string about the player using the item.
+func (item Item) generateUseMessage(outcome Outcome) string {
+	definition, ok := itemDefinitions[item]
+	if !ok {
+		return ""
+	}
+
+	if item == HealingSalve {
+		return fmt.Sprintf(definition.useMessage, outcome.HealthAfter-outcome.HealthBefore, outcome.HealthAfter)
+	}
+
+	return definition.useMessage
+}
+
+// ParseItem returns the item named by key the way it appears in ruleset files, e.g.
+// "salve", or false if no item goes by that name.
+func ParseItem(key string) (Item, bool) {
+	for item, definition := range itemDefinitions {
+		if definition.key == key {
+			return item, true
+		}
+	}
+
+	return 0, false
+}
+
+// fillInventory fills the inventory with the items the config's loadout starts the player
+// with, reusing the memory of the given inventory.
+func fillInventory(inventory map[Item]uint, config GameConfig) map[Item]uint {
+	if inventory == nil {
+		inventory = make(map[Item]uint, len(itemDefinitions))
+	}
+
+	clear(inventory)
+
+	for item, count := range config.Loadout {
+		if count > 0 {
+			inventory[item] = count
+		}
+	}
+
+	return inventory
+}
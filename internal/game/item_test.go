@@ -0,0 +1,78 @@
+package game
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+)
+
+// TestItemDefinitions ensures every item is fully described and numbered from zero
+// without gaps, so dropped items can be picked by number.
+func TestItemDefinitions(t *testing.T) {
+	keys := map[string]bool{}
+
+	for item := range Item(len(itemDefinitions)) {
+		definition, ok := itemDefinitions[item]
+		if !ok {
+			t.Fatalf("Expected item %d to be defined.", item)
+		}
+
+		if definition.name == "" || definition.key == "" || definition.description == "" || definition.useMessage == "" {
+			t.Errorf("Item %d is missing its name, key, description or message: %+v", item, definition)
+		}
+
+		if keys[definition.key] {
+			t.Errorf("Item key %q is used more than once.", definition.key)
+		}
+
+		keys[definition.key] = true
+	}
+}
+
+// TestParseItem ensures items are found by the key they use in ruleset files and commands.
+func TestParseItem(t *testing.T) {
+	for item, definition := range itemDefinitions {
+		if parsed, ok := ParseItem(definition.key); !ok || parsed != item {
+			t.Errorf("Expected %q to be the %s. Received: %s, %t", definition.key, item, parsed, ok)
+		}
+	}
+
+	if _, ok := ParseItem("honey"); ok {
+		t.Errorf("Expected \"honey\" not to be an item.")
+	}
+}
+
+// TestFillInventory ensures the inventory holds the loadout, leaves out items the player
+// starts without and reuses the memory it's given.
+func TestFillInventory(t *testing.T) {
+	config := DefaultGameConfig()
+	config.Loadout = map[Item]uint{HealingSalve: 2, SmokeCanister: 0}
+
+	inventory := fillInventory(nil, config)
+	if !reflect.DeepEqual(inventory, map[Item]uint{HealingSalve: 2}) {
+		t.Fatalf("Expected the inventory to hold the loadout. Received: %v", inventory)
+	}
+
+	inventory[Antihistamine] = 3
+	refilled := fillInventory(inventory, config)
+
+	if !maps.Equal(refilled, map[Item]uint{HealingSalve: 2}) || reflect.ValueOf(refilled).Pointer() != reflect.ValueOf(inventory).Pointer() {
+		t.Errorf("Expected the inventory to be refilled in place. Received: %v", refilled)
+	}
+}
+
+// TestItemGenerateUseMessage ensures healing items report the hit points restored.
+func TestItemGenerateUseMessage(t *testing.T) {
+	message := HealingSalve.generateUseMessage(Outcome{HealthBefore: 60, HealthAfter: 85})
+	if message != "You apply a healing salve and recover 25 HP. You have 85 HP left." {
+		t.Errorf("Unexpected message for the healing salve: %q", message)
+	}
+
+	if message := SmokeCanister.generateUseMessage(Outcome{}); message != itemDefinitions[SmokeCanister].useMessage {
+		t.Errorf("Unexpected message for the smoke canister: %q", message)
+	}
+
+	if message := Item(9).generateUseMessage(Outcome{}); message != "" {
+		t.Errorf("Expected no message for an unknown item. Received: %q", message)
+	}
+}
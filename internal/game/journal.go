@@ -0,0 +1,121 @@
+package game
+
+import "fmt"
+
+// JournalEntry records a single action taken during the game: who acted, against whom,
+// whether it landed and how much damage it did.
+type JournalEntry struct {
+	Round    uint
+	Type     EventType
+	ByPlayer bool    // Whether the player acted. Otherwise the bee in the outcome acted.
+	Missed   bool    // Whether the attack missed its target.
+	Outcome  Outcome // The bee involved, the roll and the damage done to the target.
+}
+
+// journalResults describes the result of every event type in the journal.
+var journalResults = map[EventType]string{
+	PlayerMissed: "missed",
+	BeeHit:       "hit",
+	BeeKilled:    "killed",
+	QueenKilled:  "killed",
+	BeeMissed:    "missed",
+	PlayerStung:  "stung",
+	PlayerKilled: "killed",
+	BeesSwarmed:  "swarmed",
+}
+
+// createJournalEntry records an event of the given type that happened during the round.
+func createJournalEntry(round uint, eventType EventType, outcome Outcome) JournalEntry {
+	return JournalEntry{
+		Round:    round,
+		Type:     eventType,
+		ByPlayer: eventType == PlayerMissed || eventType == BeeHit || eventType == BeeKilled || eventType == QueenKilled || eventType == PlayerDefended || eventType == ItemUsed || eventType == PlayerStunned,
+		Missed:   eventType == PlayerMissed || eventType == BeeMissed || (eventType == BeesSwarmed && outcome.SwarmStings == 0),
+		Outcome:  outcome,
+	}
+}
+
+// String returns a one line description of the entry, e.g.
+// "Round 3: You → drone bee: hit for 30 damage (60 → 30 HP)". Attacks the player aimed
+// at the bee's type are marked "(aimed)", critical hits are called out, items dropped by
+// the bees killed are named, and so are the status effects hits and stings left behind.
+// Swarms are told by the number of bees in them.
+func (entry JournalEntry) String() string {
+	switch entry.Type {
+	case HiveCollapsed:
+		return fmt.Sprintf("Round %d: The hive collapsed", entry.Round)
+	case PlayerDefended:
+		return fmt.Sprintf("Round %d: You defended", entry.Round)
+	case ItemUsed:
+		if entry.Outcome.HealthAfter != entry.Outcome.HealthBefore {
+			return fmt.Sprintf("Round %d: You used %s (%d → %d HP)", entry.Round, entry.Outcome.Item, entry.Outcome.HealthBefore, entry.Outcome.HealthAfter)
+		}
+
+		return fmt.Sprintf("Round %d: You used %s", entry.Round, entry.Outcome.Item)
+	case PlayerStunned:
+		return fmt.Sprintf("Round %d: You were stunned", entry.Round)
+	case PlayerPoisoned, BeePoisoned:
+		target := "You"
+		if entry.Type == BeePoisoned {
+			target = entry.Outcome.BeeType.String()
+		}
+
+		return fmt.Sprintf("Round %d: Poison → %s: %d damage (%d → %d HP)", entry.Round, target, entry.Outcome.Damage, entry.Outcome.HealthBefore, entry.Outcome.HealthAfter)
+	case QueenFed:
+		return fmt.Sprintf("Round %d: %s → %s: fed %d HP (%d → %d HP)", entry.Round, entry.Outcome.BeeType, QueenBee, entry.Outcome.HealthAfter-entry.Outcome.HealthBefore, entry.Outcome.HealthBefore, entry.Outcome.HealthAfter)
+	case QueenCommanded:
+		return fmt.Sprintf("Round %d: %s issued a royal command", entry.Round, entry.Outcome.BeeType)
+	case DronesHatched:
+		return fmt.Sprintf("Round %d: %s laid eggs: %d %ss hatched", entry.Round, QueenBee, entry.Outcome.Hatched, entry.Outcome.BeeType)
+	}
+
+	actor, target := "You", entry.Outcome.BeeType.String()
+	if !entry.ByPlayer {
+		actor, target = target, "You"
+
+		if entry.Outcome.Swarm > 0 {
+			actor = fmt.Sprintf("%d %ss", entry.Outcome.Swarm, entry.Outcome.BeeType)
+		}
+	} else if entry.Outcome.Aimed {
+		target += " (aimed)"
+	}
+
+	if entry.Missed {
+		return fmt.Sprintf("Round %d: %s → %s: missed", entry.Round, actor, target)
+	}
+
+	damage := fmt.Sprintf("%d damage", entry.Outcome.Damage)
+	if entry.Outcome.Critical {
+		damage = fmt.Sprintf("%d critical damage", entry.Outcome.Damage)
+	}
+
+	if entry.Outcome.Blocked > 0 {
+		damage += fmt.Sprintf(", %d blocked", entry.Outcome.Blocked)
+	}
+
+	description := fmt.Sprintf(
+		"Round %d: %s → %s: %s for %s (%d → %d HP)",
+		entry.Round,
+		actor,
+		target,
+		journalResults[entry.Type],
+		damage,
+		entry.Outcome.HealthBefore,
+		entry.Outcome.HealthAfter,
+	)
+
+	if entry.Outcome.ItemDropped {
+		description += fmt.Sprintf(", dropped %s", entry.Outcome.Item)
+	}
+
+	if entry.Outcome.EffectInflicted {
+		victim := "you"
+		if entry.ByPlayer {
+			victim = "it"
+		}
+
+		description += fmt.Sprintf(", left %s %s", victim, entry.Outcome.Effect)
+	}
+
+	return description
+}
@@ -0,0 +1,83 @@
+package game
+
+import "testing"
+
+// TestCreateJournalEntry ensures that each event type records who acted and whether the attack missed.
+func TestCreateJournalEntry(t *testing.T) {
+	scenarios := []struct {
+		eventType EventType
+		byPlayer  bool
+		missed    bool
+	}{
+		{PlayerMissed, true, true},
+		{BeeHit, true, false},
+		{BeeKilled, true, false},
+		{QueenKilled, true, false},
+		{BeeMissed, false, true},
+		{PlayerStung, false, false},
+		{PlayerKilled, false, false},
+		{HiveCollapsed, false, false},
+		{PlayerStunned, true, false},
+		{PlayerPoisoned, false, false},
+		{BeePoisoned, false, false},
+		{QueenFed, false, false},
+		{BeesSwarmed, false, true},
+		{QueenCommanded, false, false},
+		{DronesHatched, false, false},
+	}
+
+	for _, scenario := range scenarios {
+		entry := createJournalEntry(7, scenario.eventType, Outcome{Damage: 3})
+
+		if entry.Round != 7 || entry.Type != scenario.eventType || entry.Outcome.Damage != 3 {
+			t.Errorf("createJournalEntry did not record the %s event: %+v", scenario.eventType, entry)
+		}
+
+		if entry.ByPlayer != scenario.byPlayer {
+			t.Errorf("Unexpected actor for %s. Expected ByPlayer: %t.", scenario.eventType, scenario.byPlayer)
+		}
+
+		if entry.Missed != scenario.missed {
+			t.Errorf("Unexpected result for %s. Expected Missed: %t.", scenario.eventType, scenario.missed)
+		}
+	}
+}
+
+// TestJournalEntryString verifies the one line descriptions of journal entries.
+func TestJournalEntryString(t *testing.T) {
+	scenarios := []struct {
+		entry       JournalEntry
+		expectedStr string
+	}{
+		{createJournalEntry(1, PlayerMissed, Outcome{BeeType: DroneBee, HealthBefore: 60, HealthAfter: 60}), "Round 1: You → drone bee: missed"},
+		{createJournalEntry(2, BeeHit, Outcome{BeeType: DroneBee, Damage: 30, HealthBefore: 60, HealthAfter: 30}), "Round 2: You → drone bee: hit for 30 damage (60 → 30 HP)"},
+		{createJournalEntry(2, BeeHit, Outcome{BeeType: QueenBee, Damage: 10, HealthBefore: 100, HealthAfter: 90, Aimed: true}), "Round 2: You → Queen bee (aimed): hit for 10 damage (100 → 90 HP)"},
+		{createJournalEntry(3, QueenKilled, Outcome{BeeType: QueenBee, Damage: 10, HealthBefore: 10, HealthAfter: 0}), "Round 3: You → Queen bee: killed for 10 damage (10 → 0 HP)"},
+		{createJournalEntry(4, BeeMissed, Outcome{BeeType: WorkerBee, HealthBefore: 100, HealthAfter: 100}), "Round 4: worker bee → You: missed"},
+		{createJournalEntry(5, PlayerStung, Outcome{BeeType: WorkerBee, Damage: 5, HealthBefore: 100, HealthAfter: 95}), "Round 5: worker bee → You: stung for 5 damage (100 → 95 HP)"},
+		{createJournalEntry(5, PlayerStung, Outcome{BeeType: QueenBee, Damage: 5, Blocked: 5, HealthBefore: 100, HealthAfter: 95}), "Round 5: Queen bee → You: stung for 5 damage, 5 blocked (100 → 95 HP)"},
+		{createJournalEntry(6, HiveCollapsed, Outcome{}), "Round 6: The hive collapsed"},
+		{createJournalEntry(3, BeeKilled, Outcome{BeeType: WorkerBee, Damage: 25, HealthBefore: 25, HealthAfter: 0, Item: SmokeCanister, ItemDropped: true}), "Round 3: You → worker bee: killed for 25 damage (25 → 0 HP), dropped smoke canister"},
+		{createJournalEntry(4, ItemUsed, Outcome{Item: HealingSalve, HealthBefore: 50, HealthAfter: 75}), "Round 4: You used healing salve (50 → 75 HP)"},
+		{createJournalEntry(4, ItemUsed, Outcome{Item: Antihistamine, HealthBefore: 50, HealthAfter: 50}), "Round 4: You used antihistamine"},
+		{createJournalEntry(7, PlayerDefended, Outcome{HealthBefore: 95, HealthAfter: 95}), "Round 7: You defended"},
+		{createJournalEntry(5, PlayerStung, Outcome{BeeType: WorkerBee, Damage: 5, HealthBefore: 100, HealthAfter: 95, Effect: Poisoned, EffectInflicted: true}), "Round 5: worker bee → You: stung for 5 damage (100 → 95 HP), left you poisoned"},
+		{createJournalEntry(6, BeeHit, Outcome{BeeType: DroneBee, Damage: 30, HealthBefore: 60, HealthAfter: 30, Effect: Stunned, EffectInflicted: true}), "Round 6: You → drone bee: hit for 30 damage (60 → 30 HP), left it stunned"},
+		{createJournalEntry(2, BeeHit, Outcome{BeeType: DroneBee, Damage: 45, HealthBefore: 60, HealthAfter: 15, Critical: true}), "Round 2: You → drone bee: hit for 45 critical damage (60 → 15 HP)"},
+		{createJournalEntry(8, PlayerStunned, Outcome{HealthBefore: 95, HealthAfter: 95}), "Round 8: You were stunned"},
+		{createJournalEntry(9, PlayerPoisoned, Outcome{Damage: 5, HealthBefore: 95, HealthAfter: 90}), "Round 9: Poison → You: 5 damage (95 → 90 HP)"},
+		{createJournalEntry(9, BeePoisoned, Outcome{BeeType: DroneBee, Damage: 5, HealthBefore: 5, HealthAfter: 0}), "Round 9: Poison → drone bee: 5 damage (5 → 0 HP)"},
+		{createJournalEntry(3, QueenFed, Outcome{BeeType: WorkerBee, HealthBefore: 70, HealthAfter: 80}), "Round 3: worker bee → Queen bee: fed 10 HP (70 → 80 HP)"},
+		{createJournalEntry(4, QueenCommanded, Outcome{BeeType: QueenBee, HealthBefore: 100, HealthAfter: 100}), "Round 4: Queen bee issued a royal command"},
+		{createJournalEntry(5, BeesSwarmed, Outcome{BeeType: DroneBee, Swarm: 3, SwarmStings: 2, Damage: 4, HealthBefore: 100, HealthAfter: 96}), "Round 5: 3 drone bees → You: swarmed for 4 damage (100 → 96 HP)"},
+		{createJournalEntry(5, BeesSwarmed, Outcome{BeeType: DroneBee, Swarm: 3, HealthBefore: 100, HealthAfter: 100}), "Round 5: 3 drone bees → You: missed"},
+		{createJournalEntry(6, DronesHatched, Outcome{BeeIndex: 20, BeeType: DroneBee, Hatched: 3}), "Round 6: Queen bee laid eggs: 3 drone bees hatched"},
+		{createJournalEntry(6, PlayerKilled, Outcome{BeeType: DroneBee, Swarm: 2, SwarmStings: 2, Damage: 4, HealthBefore: 4, HealthAfter: 0}), "Round 6: 2 drone bees → You: killed for 4 damage (4 → 0 HP)"},
+	}
+
+	for _, scenario := range scenarios {
+		if received := scenario.entry.String(); received != scenario.expectedStr {
+			t.Errorf("Unexpected journal entry description. Expected: \"%s\". Received: \"%s\"", scenario.expectedStr, received)
+		}
+	}
+}
@@ -2,48 +2,73 @@ package game
 
 import "fmt"
 
-const (
-	damageFromQueen  = 10
-	damageFromWorker = 5
-	damageFromDrone  = 1
-)
-
 // Player represents the player character in the game.
-// It tracks the player's health and the probability that their attack will miss.
+// It tracks the player's health, the probability that their attack will miss, the
+// items they carry, the status effects they are under and whether they are bracing
+// themselves against the next sting.
 type Player struct {
 	Health     int
 	MissChance uint
+	Defense    uint // Percentage of a sting's damage blocked while defending.
+	Defending  bool
+	Immune     bool          // Whether the next sting that lands is shrugged off entirely.
+	Inventory  map[Item]uint // Number of each item the player carries. Items they've run out of are left out.
+	Effects    Effects
 }
 
-// createPlayer initializes a new Player instance with default or custom miss chance.
-// If no miss chance is provided, a default value of 10% is used.
-func createPlayer(missChance ...uint) Player {
-	var miss uint
+// createPlayer initializes a new Player instance with the health, miss chance, defense
+// and loadout given by the config.
+func createPlayer(config GameConfig) Player {
+	return fillPlayer(nil, config)
+}
 
-	if len(missChance) > 0 {
-		miss = missChance[0]
-	} else {
-		miss = 10
+// fillPlayer initializes a new Player instance like createPlayer, reusing the memory of
+// the given inventory.
+func fillPlayer(inventory map[Item]uint, config GameConfig) Player {
+	return Player{
+		Health:     config.PlayerHealth,
+		MissChance: config.PlayerMissChance,
+		Defense:    config.PlayerDefense,
+		Inventory:  fillInventory(inventory, config),
 	}
+}
 
-	return Player{
-		Health:     100,
-		MissChance: miss,
+// addItem puts an item in the player's inventory.
+func (player *Player) addItem(item Item) {
+	if player.Inventory == nil {
+		player.Inventory = map[Item]uint{}
 	}
+
+	player.Inventory[item]++
 }
 
-// takeDamage applies damage to the player based on the type of bee attacking.
-// Returns true if the player's health drops to zero or below (i.e., the player dies).
-func (player *Player) takeDamage(beeType BeeType) bool {
-	damage := 0
+// removeItem takes an item out of the player's inventory. It returns false if the player
+// had none left.
+func (player *Player) removeItem(item Item) bool {
+	if player.Inventory[item] == 0 {
+		return false
+	}
 
-	switch beeType {
-	case QueenBee:
-		damage = damageFromQueen
-	case WorkerBee:
-		damage = damageFromWorker
-	case DroneBee:
-		damage = damageFromDrone
+	player.Inventory[item]--
+	if player.Inventory[item] == 0 {
+		delete(player.Inventory, item)
+	}
+
+	return true
+}
+
+// takeDamage deducts the damage dealt by a sting from the player's health, less the
+// share the player blocks if they are defending. The share is rounded up, so a defense
+// blocks at least a point of any sting. An immune player takes no damage, but loses
+// their immunity.
+// Returns true if the player's health drops to zero or below (i.e., the player dies).
+func (player *Player) takeDamage(damage int) bool {
+	switch {
+	case player.Immune:
+		damage = 0
+		player.Immune = false
+	case player.Defending:
+		damage -= (damage*int(player.Defense) + 99) / 100
 	}
 
 	player.Health -= damage
@@ -52,24 +77,21 @@ func (player *Player) takeDamage(beeType BeeType) bool {
 }
 
 // generateHitMessage returns a descriptive string based on the type of bee that attacked
-// the player and whether the attack was fatal.
-func (player *Player) generateHitMessage(beeType BeeType, died bool) string {
-	msg := ""
+// the player, the damage it dealt and whether the attack was fatal or a critical hit.
+func (player *Player) generateHitMessage(beeType BeeType, damage int, died, critical bool) string {
+	definition, ok := beeDefinitions[beeType]
+	if !ok {
+		return ""
+	}
 
 	switch {
-	case beeType == QueenBee && died:
-		msg = "The Queen bee just killed you!"
-	case beeType == QueenBee && !died:
-		msg = fmt.Sprintf("Sting! You just got stun by the Queen bee. You have %d HP left.", player.Health)
-	case beeType == WorkerBee && died:
-		msg = "A worker bee just killed you!"
-	case beeType == WorkerBee && !died:
-		msg = fmt.Sprintf("Sting! You just got stun by a worker bee. You have %d HP left.", player.Health)
-	case beeType == DroneBee && died:
-		msg = "A drone bee just killed you!"
-	case beeType == DroneBee && !died:
-		msg = fmt.Sprintf("Sting! You just got stun by a drone bee. You have %d HP left.", player.Health)
+	case died && critical:
+		return "Critical sting! " + definition.fatalStingMessage
+	case died:
+		return definition.fatalStingMessage
+	case critical:
+		return fmt.Sprintf(definition.critStingMessage, damage, player.Health)
 	}
 
-	return msg
+	return fmt.Sprintf(definition.stingMessage, player.Health)
 }
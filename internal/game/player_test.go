@@ -3,32 +3,35 @@ package game
 import "testing"
 
 // TestCreatePlayer verifies the behavior of the createPlayer function.
-// It ensures the player is initialized with the correct default health (100)
-// and that the miss chance is set correctly when specified or omitted.
+// It ensures the player is initialized with the health and miss chance from the config.
 func TestCreatePlayer(t *testing.T) {
-	missChance := 35
+	config := DefaultGameConfig()
+	config.PlayerHealth = 250
+	config.PlayerMissChance = 35
 
-	playerWithMissChance := createPlayer(uint(missChance))
+	player := createPlayer(config)
 
-	if playerWithMissChance.Health != 100 {
-		t.Errorf("createPlayer generated player with incorrect health. Expected: %d. Received: %d.\n", 100, playerWithMissChance.Health)
+	if player.Health != config.PlayerHealth {
+		t.Errorf("createPlayer generated player with incorrect health. Expected: %d. Received: %d.\n", config.PlayerHealth, player.Health)
 	}
 
-	if playerWithMissChance.MissChance != uint(missChance) {
-		t.Errorf("createPlayer generated player with incorrect miss chance. Expected: %d. Received: %d.\n", missChance, playerWithMissChance.MissChance)
+	if player.MissChance != config.PlayerMissChance {
+		t.Errorf("createPlayer generated player with incorrect miss chance. Expected: %d. Received: %d.\n", config.PlayerMissChance, player.MissChance)
 	}
 
-	// Test player creation with default miss chance.
-	missChance = 10
+	if player.Defense != config.PlayerDefense || player.Defending {
+		t.Errorf("createPlayer generated player with incorrect defense. Expected: %d. Received: %d.\n", config.PlayerDefense, player.Defense)
+	}
 
-	playerWithoutMissChance := createPlayer()
+	// Test player creation with the default rules.
+	player = createPlayer(DefaultGameConfig())
 
-	if playerWithoutMissChance.Health != 100 {
-		t.Errorf("createPlayer generated player with incorrect health. Expected: %d. Received: %d.\n", 100, playerWithoutMissChance.Health)
+	if player.Health != 100 {
+		t.Errorf("createPlayer generated player with incorrect health. Expected: %d. Received: %d.\n", 100, player.Health)
 	}
 
-	if playerWithoutMissChance.MissChance != uint(missChance) {
-		t.Errorf("createPlayer generated player with incorrect miss chance. Expected: %d. Received: %d.\n", missChance, playerWithoutMissChance.MissChance)
+	if player.MissChance != 10 {
+		t.Errorf("createPlayer generated player with incorrect miss chance. Expected: %d. Received: %d.\n", 10, player.MissChance)
 	}
 }
 
@@ -41,12 +44,12 @@ func TestPlayerTakeDamage(t *testing.T) {
 		beeType        BeeType
 		shouldHaveDied bool
 	}{
-		{damageFromQueen + 5, 5, QueenBee, false},
-		{damageFromQueen - 5, -5, QueenBee, true},
-		{damageFromWorker + 5, 5, WorkerBee, false},
-		{damageFromWorker - 5, -5, WorkerBee, true},
-		{damageFromDrone + 5, 5, DroneBee, false},
-		{damageFromDrone - 5, -5, DroneBee, true},
+		{beeDefinitions[QueenBee].DamageDealt + 5, 5, QueenBee, false},
+		{beeDefinitions[QueenBee].DamageDealt - 5, -5, QueenBee, true},
+		{beeDefinitions[WorkerBee].DamageDealt + 5, 5, WorkerBee, false},
+		{beeDefinitions[WorkerBee].DamageDealt - 5, -5, WorkerBee, true},
+		{beeDefinitions[DroneBee].DamageDealt + 5, 5, DroneBee, false},
+		{beeDefinitions[DroneBee].DamageDealt - 5, -5, DroneBee, true},
 	}
 
 	for _, scenario := range scenarios {
@@ -54,7 +57,7 @@ func TestPlayerTakeDamage(t *testing.T) {
 			Health: scenario.playerHealth,
 		}
 
-		died := player.takeDamage(scenario.beeType)
+		died := player.takeDamage(beeDefinitions[scenario.beeType].DamageDealt)
 
 		if player.Health != scenario.expectedHealth {
 			t.Errorf("Player's health is different from what was expected after an attack. Expected health: %d. Current health: %d. Bee type: %s\n", scenario.expectedHealth, player.Health, scenario.beeType)
@@ -70,6 +73,62 @@ func TestPlayerTakeDamage(t *testing.T) {
 	}
 }
 
+// TestPlayerTakeDamageDefending ensures a defending player only takes the share of the
+// damage their defense doesn't block.
+func TestPlayerTakeDamageDefending(t *testing.T) {
+	player := &Player{Health: 100, Defense: 50, Defending: true}
+
+	if died := player.takeDamage(10); died || player.Health != 95 {
+		t.Errorf("Expected the defending player to take 5 damage. Received: %d HP left.", player.Health)
+	}
+
+	// The blocked share is rounded up, so even a drone's 1 damage sting is blocked.
+	if player.takeDamage(1); player.Health != 95 {
+		t.Errorf("Expected the defending player to block a 1 damage sting. Received: %d HP left.", player.Health)
+	}
+
+	player = &Player{Health: 100, Defense: 50}
+
+	if player.takeDamage(10); player.Health != 90 {
+		t.Errorf("Expected the player to take the full damage when not defending. Received: %d HP left.", player.Health)
+	}
+}
+
+// TestPlayerTakeDamageImmune ensures an immune player shrugs off a single sting, even
+// while defending.
+func TestPlayerTakeDamageImmune(t *testing.T) {
+	player := &Player{Health: 10, Defense: 50, Defending: true, Immune: true}
+
+	if died := player.takeDamage(20); died || player.Health != 10 || player.Immune {
+		t.Fatalf("Expected the immune player to take no damage and lose their immunity. Received: %+v", player)
+	}
+
+	if player.takeDamage(20); player.Health != 0 {
+		t.Errorf("Expected the next sting to be blocked by the player's defense alone. Received: %d HP left.", player.Health)
+	}
+}
+
+// TestPlayerInventory ensures items are added to and taken from the inventory, and that
+// items the player runs out of are left out.
+func TestPlayerInventory(t *testing.T) {
+	player := &Player{}
+
+	player.addItem(SmokeCanister)
+	player.addItem(SmokeCanister)
+
+	if player.Inventory[SmokeCanister] != 2 {
+		t.Fatalf("Expected the player to carry 2 smoke canisters. Received: %v", player.Inventory)
+	}
+
+	if !player.removeItem(SmokeCanister) || !player.removeItem(SmokeCanister) || player.removeItem(SmokeCanister) {
+		t.Fatal("Expected exactly 2 smoke canisters to be taken out of the inventory.")
+	}
+
+	if len(player.Inventory) != 0 {
+		t.Errorf("Expected the inventory to be empty. Received: %v", player.Inventory)
+	}
+}
+
 // TestPlayerGenerateHitMessage checks the message returned when a player is hit by a bee.
 // It confirms that the correct death or survival message is returned based on bee type and remaining health.
 func TestPlayerGenerateHitMessage(t *testing.T) {
@@ -93,10 +152,24 @@ func TestPlayerGenerateHitMessage(t *testing.T) {
 			Health: scenario.playerHealth,
 		}
 
-		msg := player.generateHitMessage(scenario.beeType, scenario.died)
+		msg := player.generateHitMessage(scenario.beeType, 1, scenario.died, false)
 
 		if msg != scenario.expectedMsg {
 			t.Errorf("Unexpected message generated from generateHitMessage function. Expected: \"%s\". Generated: \"%s\"\n", scenario.expectedMsg, msg)
 		}
 	}
 }
+
+// TestPlayerGenerateCriticalHitMessage checks the messages returned when a bee's sting is
+// a critical hit.
+func TestPlayerGenerateCriticalHitMessage(t *testing.T) {
+	player := &Player{Health: 85}
+
+	if msg := player.generateHitMessage(WorkerBee, 7, false, true); msg != "Critical sting! A worker bee stung you for 7 damage. You have 85 HP left." {
+		t.Errorf("Unexpected message for a critical sting: %q", msg)
+	}
+
+	if msg := player.generateHitMessage(QueenBee, 15, true, true); msg != "Critical sting! The Queen bee just killed you!" {
+		t.Errorf("Unexpected message for a fatal critical sting: %q", msg)
+	}
+}
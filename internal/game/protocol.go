@@ -1,12 +1,81 @@
 package game
 
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrQuit is returned by the protocol once either side has quit the game.
+var ErrQuit = errors.New("game: the game was quit")
+
+// Protocol describes how the client and the game server talk to each other.
+// Every blocking operation gives up when its context is cancelled or the game is quit,
+// so neither side can be left waiting on the other forever.
+//
+// A move the server accepts may be answered with DronesHatched events first, for the
+// drones that hatched at the start of the round. The event describing the move, and
+// then the hive's, follow through WaitForCPU.
 type Protocol interface {
-	Hit() Event
-	WaitForCPU() Event
-	WaitForPlayer()
-	HitResponse(string, GameState)
-	StingResponse(string, GameState)
-	GameFinishedResponse(string, GameState)
+	Hit(context.Context) (Event, error)
+	Aim(context.Context, BeeType) (Event, error)
+	Defend(context.Context) (Event, error)
+	Use(context.Context, Item) (Event, error)
+	Save(context.Context) (SavedGame, error)
+	Load(context.Context, SavedGame) (GameState, error)
+	WaitForCPU(context.Context) (Event, error)
+	WaitForPlayer(context.Context) (Request, error)
+	HitResponse(context.Context, Event) error
+	StingResponse(context.Context, Event) error
+	GameFinishedResponse(context.Context, Event) error
+	SaveResponse(context.Context, SavedGame, error) error
+	LoadResponse(context.Context, GameState, error) error
+	RejectResponse(context.Context, error) error
+	Quit()
+}
+
+// RequestType identifies what the player asked the server to do on their turn.
+type RequestType uint
+
+const (
+	// HitRequest asks the server to play the player's attack on a random bee.
+	HitRequest RequestType = iota
+
+	// AimRequest asks the server to play the player's attack on a bee of the target type.
+	AimRequest
+
+	// DefendRequest asks the server to have the player brace against the next sting
+	// instead of attacking.
+	DefendRequest
+
+	// UseRequest asks the server to have the player use an item instead of attacking.
+	UseRequest
+
+	// SaveRequest asks the server for a SavedGame of the game so far.
+	SaveRequest
+
+	// LoadRequest asks the server to resume the game from a SavedGame.
+	LoadRequest
+)
+
+// Request is sent from the client to the server while the server waits for the player.
+type Request struct {
+	Type   RequestType
+	Target BeeType   // The type of bee to attack for an AimRequest.
+	Item   Item      // The item to use for a UseRequest.
+	Save   SavedGame // The game to resume for a LoadRequest.
+}
+
+// saveReply carries the server's answer to a SaveRequest.
+type saveReply struct {
+	save SavedGame
+	err  error
+}
+
+// loadReply carries the server's answer to a LoadRequest.
+type loadReply struct {
+	state GameState
+	err   error
 }
 
 // CommunicationProtocol encapsulates the message flow between
@@ -15,65 +84,189 @@ type Protocol interface {
 // The protocol uses channels to coordinate player input and emit
 // game events in response to game logic execution.
 type CommunicationProtocol struct {
-	hitSignal    chan struct{}
-	eventChannel chan Event
+	requests      chan Request
+	eventChannel  chan Event
+	saveChannel   chan saveReply
+	loadChannel   chan loadReply
+	rejectChannel chan error
+	quit          chan struct{}
+	quitOnce      sync.Once
 }
 
 // createCommunicationProtocol initializes and returns a new CommunicationProtocol instance.
 func createCommunicationProtocol() *CommunicationProtocol {
 	return &CommunicationProtocol{
-		hitSignal:    make(chan struct{}),
-		eventChannel: make(chan Event),
+		requests:      make(chan Request),
+		eventChannel:  make(chan Event),
+		saveChannel:   make(chan saveReply),
+		loadChannel:   make(chan loadReply),
+		rejectChannel: make(chan error),
+		quit:          make(chan struct{}),
 	}
 }
 
 // Hit is called when the player takes an action. It blocks until the server
 // processes the player's move and returns an Event describing the outcome.
-func (protocol *CommunicationProtocol) Hit() Event {
-	protocol.hitSignal <- struct{}{}
+func (protocol *CommunicationProtocol) Hit(ctx context.Context) (Event, error) {
+	if err := send(ctx, protocol.quit, protocol.requests, Request{Type: HitRequest}); err != nil {
+		return Event{}, err
+	}
 
-	return <-protocol.eventChannel
+	return receive(ctx, protocol.quit, protocol.eventChannel)
 }
 
-// WaitForCPU blocks until the hive has finished it's turn.
-func (protocol *CommunicationProtocol) WaitForCPU() Event {
-	return <-protocol.eventChannel
+// Aim is called when the player attacks a bee of the target type. It blocks until the
+// server processes the player's move and returns an Event describing the outcome, or
+// the reason the server turned the attack down, in which case the player keeps their turn.
+func (protocol *CommunicationProtocol) Aim(ctx context.Context, target BeeType) (Event, error) {
+	if err := send(ctx, protocol.quit, protocol.requests, Request{Type: AimRequest, Target: target}); err != nil {
+		return Event{}, err
+	}
+
+	return protocol.receiveMove(ctx)
+}
+
+// Use is called when the player uses an item instead of attacking. It blocks until the
+// server processes the player's move and returns an Event describing the outcome, or
+// the reason the server turned it down, in which case the player keeps their turn.
+func (protocol *CommunicationProtocol) Use(ctx context.Context, item Item) (Event, error) {
+	if err := send(ctx, protocol.quit, protocol.requests, Request{Type: UseRequest, Item: item}); err != nil {
+		return Event{}, err
+	}
+
+	return protocol.receiveMove(ctx)
 }
 
-// waitForPlayer blocks until a player action is received.
-func (protocol *CommunicationProtocol) WaitForPlayer() {
-	<-protocol.hitSignal
+// receiveMove waits for the event describing the player's move, or the reason the server
+// turned it down.
+func (protocol *CommunicationProtocol) receiveMove(ctx context.Context) (Event, error) {
+	select {
+	case event := <-protocol.eventChannel:
+		return event, nil
+	case err := <-protocol.rejectChannel:
+		return Event{}, err
+	case <-protocol.quit:
+		return Event{}, ErrQuit
+	case <-ctx.Done():
+		return Event{}, ctx.Err()
+	}
+}
+
+// Defend is called when the player braces themselves instead of attacking. It blocks
+// until the server processes the player's move and returns an Event describing it.
+func (protocol *CommunicationProtocol) Defend(ctx context.Context) (Event, error) {
+	if err := send(ctx, protocol.quit, protocol.requests, Request{Type: DefendRequest}); err != nil {
+		return Event{}, err
+	}
+
+	return receive(ctx, protocol.quit, protocol.eventChannel)
 }
 
-// hitResponse sends an event indicating the player has performed an attack.
-func (protocol *CommunicationProtocol) HitResponse(msg string, state GameState) {
-	event := Event{
-		Type:    PlayerAttack,
-		Message: msg,
-		State:   state,
+// Save asks the server for a SavedGame of the game so far. The player keeps their turn.
+func (protocol *CommunicationProtocol) Save(ctx context.Context) (SavedGame, error) {
+	if err := send(ctx, protocol.quit, protocol.requests, Request{Type: SaveRequest}); err != nil {
+		return SavedGame{}, err
+	}
+
+	reply, err := receive(ctx, protocol.quit, protocol.saveChannel)
+	if err != nil {
+		return SavedGame{}, err
 	}
 
-	protocol.eventChannel <- event
+	return reply.save, reply.err
 }
 
-// stingResponse sends an event indicating the hive has performed an attack.
-func (protocol *CommunicationProtocol) StingResponse(msg string, state GameState) {
-	event := Event{
-		Type:    HiveAttack,
-		Message: msg,
-		State:   state,
+// Load asks the server to replace the current game with the saved one and returns the
+// state the game resumes from. The player keeps their turn.
+func (protocol *CommunicationProtocol) Load(ctx context.Context, save SavedGame) (GameState, error) {
+	if err := send(ctx, protocol.quit, protocol.requests, Request{Type: LoadRequest, Save: save}); err != nil {
+		return GameState{}, err
 	}
 
-	protocol.eventChannel <- event
+	reply, err := receive(ctx, protocol.quit, protocol.loadChannel)
+	if err != nil {
+		return GameState{}, err
+	}
+
+	return reply.state, reply.err
+}
+
+// WaitForCPU blocks until the hive has finished it's turn.
+func (protocol *CommunicationProtocol) WaitForCPU(ctx context.Context) (Event, error) {
+	return receive(ctx, protocol.quit, protocol.eventChannel)
+}
+
+// waitForPlayer blocks until a request from the player is received.
+func (protocol *CommunicationProtocol) WaitForPlayer(ctx context.Context) (Request, error) {
+	return receive(ctx, protocol.quit, protocol.requests)
+}
+
+// hitResponse sends the event describing the outcome of the player's attack.
+func (protocol *CommunicationProtocol) HitResponse(ctx context.Context, event Event) error {
+	return send(ctx, protocol.quit, protocol.eventChannel, event)
+}
+
+// stingResponse sends the event describing the outcome of the hive's attack.
+func (protocol *CommunicationProtocol) StingResponse(ctx context.Context, event Event) error {
+	return send(ctx, protocol.quit, protocol.eventChannel, event)
 }
 
-// gameFinishedResponse sends an event indicating that the game has finished.
-func (protocol *CommunicationProtocol) GameFinishedResponse(msg string, state GameState) {
-	event := Event{
-		Type:    GameFinished,
-		Message: msg,
-		State:   state,
+// gameFinishedResponse sends the event that ended the game, marking it as finished.
+func (protocol *CommunicationProtocol) GameFinishedResponse(ctx context.Context, event Event) error {
+	event.Finished = true
+
+	return send(ctx, protocol.quit, protocol.eventChannel, event)
+}
+
+// SaveResponse answers a SaveRequest with the saved game, or the reason it couldn't be saved.
+func (protocol *CommunicationProtocol) SaveResponse(ctx context.Context, save SavedGame, err error) error {
+	return send(ctx, protocol.quit, protocol.saveChannel, saveReply{save: save, err: err})
+}
+
+// LoadResponse answers a LoadRequest with the state the game resumes from, or the reason
+// it couldn't be loaded.
+func (protocol *CommunicationProtocol) LoadResponse(ctx context.Context, state GameState, err error) error {
+	return send(ctx, protocol.quit, protocol.loadChannel, loadReply{state: state, err: err})
+}
+
+// RejectResponse turns down the player's move with the reason it can't be played. The
+// player keeps their turn.
+func (protocol *CommunicationProtocol) RejectResponse(ctx context.Context, err error) error {
+	return send(ctx, protocol.quit, protocol.rejectChannel, err)
+}
+
+// Quit ends the game for both sides. Any pending or future protocol operation returns
+// ErrQuit. It is safe to call Quit more than once and from either side.
+func (protocol *CommunicationProtocol) Quit() {
+	protocol.quitOnce.Do(func() {
+		close(protocol.quit)
+	})
+}
+
+// send delivers a value over the channel unless the context is cancelled or the game
+// is quit first.
+func send[T any](ctx context.Context, quit <-chan struct{}, channel chan<- T, value T) error {
+	select {
+	case channel <- value:
+		return nil
+	case <-quit:
+		return ErrQuit
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
+
+// receive waits for a value from the channel unless the context is cancelled or the game
+// is quit first.
+func receive[T any](ctx context.Context, quit <-chan struct{}, channel <-chan T) (T, error) {
+	var value T
 
-	protocol.eventChannel <- event
+	select {
+	case value = <-channel:
+		return value, nil
+	case <-quit:
+		return value, ErrQuit
+	case <-ctx.Done():
+		return value, ctx.Err()
+	}
 }
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -13,13 +15,21 @@ func TestCreateCommunicationProtocol(t *testing.T) {
 		t.Fatal("Expected non-nil CommunicationProtocol.")
 	}
 
-	if communication.hitSignal == nil {
-		t.Error("Expected hitSignal to be initialized.")
+	if communication.requests == nil {
+		t.Error("Expected requests to be initialized.")
+	}
+
+	if communication.saveChannel == nil || communication.loadChannel == nil {
+		t.Error("Expected saveChannel and loadChannel to be initialized.")
 	}
 
 	if communication.eventChannel == nil {
 		t.Error("Expected eventChannel to be initialized.")
 	}
+
+	if communication.quit == nil {
+		t.Error("Expected quit to be initialized.")
+	}
 }
 
 // TestHit ensures the Hit method waits for a hit signal and correctly receives an event.
@@ -27,7 +37,7 @@ func TestHit(t *testing.T) {
 	communication := createCommunicationProtocol()
 
 	expectedEvent := Event{
-		Type:    PlayerAttack,
+		Type:    BeeHit,
 		Message: "Test Message",
 		State: GameState{
 			Round: 25,
@@ -36,26 +46,107 @@ func TestHit(t *testing.T) {
 
 	go func() {
 		select {
-		case <-communication.hitSignal:
+		case request := <-communication.requests:
+			if request.Type != HitRequest {
+				t.Errorf("Expected a HitRequest. Received: %d", request.Type)
+			}
+
 			communication.eventChannel <- expectedEvent
 		case <-time.After(time.Second * 3):
-			t.Errorf("Timed out waiting for hitSignal")
+			t.Errorf("Timed out waiting for request")
 		}
 	}()
 
-	actualEvent := communication.Hit()
+	actualEvent, err := communication.Hit(context.Background())
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
 
 	if actualEvent.Type != expectedEvent.Type && actualEvent.Message != expectedEvent.Message && actualEvent.State.Round != expectedEvent.State.Round {
 		t.Errorf("Expected event does not match received event.")
 	}
 }
 
+// TestAim ensures aimed attacks carry their target and return either the event or the
+// reason the server turned them down.
+func TestAim(t *testing.T) {
+	communication := createCommunicationProtocol()
+	ctx := context.Background()
+
+	go func() {
+		for _, reject := range []bool{true, false} {
+			request, err := communication.WaitForPlayer(ctx)
+			if err != nil || request.Type != AimRequest || request.Target != WorkerBee {
+				t.Errorf("Expected an AimRequest at the worker bees. Received: %+v, %v", request, err)
+				return
+			}
+
+			if reject {
+				communication.RejectResponse(ctx, errors.New("no workers left"))
+			} else {
+				communication.HitResponse(ctx, Event{Type: BeeHit, Outcome: Outcome{BeeType: WorkerBee, Aimed: true}})
+			}
+		}
+	}()
+
+	if _, err := communication.Aim(ctx, WorkerBee); err == nil || err.Error() != "no workers left" {
+		t.Fatalf("Expected the aimed attack to be turned down. Received: %v", err)
+	}
+
+	event, err := communication.Aim(ctx, WorkerBee)
+	if err != nil || event.Type != BeeHit || !event.Outcome.Aimed {
+		t.Errorf("Expected the aimed attack to hit. Received: %+v, %v", event, err)
+	}
+}
+
+// TestDefend ensures the player's defense is sent as a DefendRequest and answered with an event.
+func TestDefend(t *testing.T) {
+	communication := createCommunicationProtocol()
+	ctx := context.Background()
+
+	go func() {
+		request, err := communication.WaitForPlayer(ctx)
+		if err != nil || request.Type != DefendRequest {
+			t.Errorf("Expected a DefendRequest. Received: %+v, %v", request, err)
+			return
+		}
+
+		communication.HitResponse(ctx, Event{Type: PlayerDefended})
+	}()
+
+	if event, err := communication.Defend(ctx); err != nil || event.Type != PlayerDefended {
+		t.Errorf("Expected the player to defend. Received: %+v, %v", event, err)
+	}
+}
+
+// TestUseRequest ensures items are sent with the UseRequest and that items the server
+// turns down are reported.
+func TestUseRequest(t *testing.T) {
+	communication := createCommunicationProtocol()
+	ctx := context.Background()
+
+	go func() {
+		request, err := communication.WaitForPlayer(ctx)
+		if err != nil || request.Type != UseRequest || request.Item != Antihistamine {
+			t.Errorf("Expected a UseRequest for an antihistamine. Received: %+v, %v", request, err)
+			return
+		}
+
+		communication.RejectResponse(ctx, errors.New("you have no antihistamine left"))
+	}()
+
+	if _, err := communication.Use(ctx, Antihistamine); err == nil || err.Error() != "you have no antihistamine left" {
+		t.Errorf("Expected the item to be turned down. Received: %v", err)
+	}
+}
+
 // TestWaitForCPU ensures CPU event messages are correctly received from the event channel.
 func TestWaitForCPU(t *testing.T) {
 	communication := createCommunicationProtocol()
 
 	expectedEvent := Event{
-		Type:    PlayerAttack,
+		Type:    BeeHit,
 		Message: "Test Message",
 		State: GameState{
 			Round: 25,
@@ -66,44 +157,63 @@ func TestWaitForCPU(t *testing.T) {
 		communication.eventChannel <- expectedEvent
 	}()
 
-	actualEvent := communication.WaitForCPU()
+	actualEvent, err := communication.WaitForCPU(context.Background())
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
 
 	if actualEvent.Type != expectedEvent.Type && actualEvent.Message != expectedEvent.Message && actualEvent.State.Round != expectedEvent.State.Round {
 		t.Errorf("Expected event does not match received event.")
 	}
 }
 
-// TestWaitForPlayer ensures that WaitForPlayer properly blocks until a signal is received.
+// TestWaitForPlayer ensures that WaitForPlayer properly blocks until a request is received.
 func TestWaitForPlayer(t *testing.T) {
 	communication := createCommunicationProtocol()
 
 	go func() {
-		communication.hitSignal <- struct{}{}
+		communication.requests <- Request{Type: SaveRequest}
 	}()
 
-	communication.WaitForPlayer()
+	request, err := communication.WaitForPlayer(context.Background())
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if request.Type != SaveRequest {
+		t.Errorf("Expected a SaveRequest. Received: %d", request.Type)
+	}
 }
 
 // TestHitResponse ensures that HitResponse sends the correct event over the channel.
 func TestHitResponse(t *testing.T) {
 	communication := createCommunicationProtocol()
 
-	msg := "Test Message"
-	state := GameState{
-		Round: 25,
+	sentEvent := Event{
+		Type:    BeeHit,
+		Message: "Test Message",
+		Outcome: Outcome{BeeIndex: 3, Damage: 10},
+		State: GameState{
+			Round: 25,
+		},
 	}
 
 	go func() {
-		communication.HitResponse(msg, state)
+		communication.HitResponse(context.Background(), sentEvent)
 	}()
 
 	select {
 	case event := <-communication.eventChannel:
-		if event.Type != PlayerAttack && event.Message != msg && event.State.Round != state.Round {
+		if event.Type != sentEvent.Type || event.Message != sentEvent.Message || event.Outcome != sentEvent.Outcome || event.State.Round != sentEvent.State.Round {
 			t.Errorf("Expected event does not match received event.")
 		}
+
+		if event.Finished != false {
+			t.Errorf("Expected event.Finished to be false.")
+		}
 	case <-time.After(time.Second * 3):
-		t.Errorf("Timed out waiting for hitSignal")
+		t.Errorf("Timed out waiting for event")
 	}
 }
 
@@ -111,44 +221,139 @@ func TestHitResponse(t *testing.T) {
 func TestStingResponse(t *testing.T) {
 	communication := createCommunicationProtocol()
 
-	msg := "Test Message"
-	state := GameState{
-		Round: 25,
+	sentEvent := Event{
+		Type:    PlayerStung,
+		Message: "Test Message",
+		Outcome: Outcome{BeeIndex: 3, Damage: 10},
+		State: GameState{
+			Round: 25,
+		},
 	}
 
 	go func() {
-		communication.StingResponse(msg, state)
+		communication.StingResponse(context.Background(), sentEvent)
 	}()
 
 	select {
 	case event := <-communication.eventChannel:
-		if event.Type != HiveAttack && event.Message != msg && event.State.Round != state.Round {
+		if event.Type != sentEvent.Type || event.Message != sentEvent.Message || event.Outcome != sentEvent.Outcome || event.State.Round != sentEvent.State.Round {
 			t.Errorf("Expected event does not match received event.")
 		}
+
+		if event.Finished != false {
+			t.Errorf("Expected event.Finished to be false.")
+		}
 	case <-time.After(time.Second * 3):
-		t.Errorf("Timed out waiting for hitSignal")
+		t.Errorf("Timed out waiting for event")
 	}
 }
 
-// TestGameFinishedResponse ensures the GameFinishedResponse sends the correct event at game end.
+// TestGameFinishedResponse ensures the GameFinishedResponse sends the event at game end, marked as finished.
 func TestGameFinishedResponse(t *testing.T) {
 	communication := createCommunicationProtocol()
 
-	msg := "Test Message"
-	state := GameState{
-		Round: 25,
+	sentEvent := Event{
+		Type:    PlayerKilled,
+		Message: "Test Message",
+		Outcome: Outcome{BeeIndex: 3, Damage: 10},
+		State: GameState{
+			Round: 25,
+		},
 	}
 
 	go func() {
-		communication.GameFinishedResponse(msg, state)
+		communication.GameFinishedResponse(context.Background(), sentEvent)
 	}()
 
 	select {
 	case event := <-communication.eventChannel:
-		if event.Type != GameFinished && event.Message != msg && event.State.Round != state.Round {
+		if event.Type != sentEvent.Type || event.Message != sentEvent.Message || event.Outcome != sentEvent.Outcome || event.State.Round != sentEvent.State.Round {
 			t.Errorf("Expected event does not match received event.")
 		}
+
+		if event.Finished != true {
+			t.Errorf("Expected event.Finished to be true.")
+		}
+	case <-time.After(time.Second * 3):
+		t.Errorf("Timed out waiting for event")
+	}
+}
+
+// TestSaveAndLoad ensures Save and Load send their requests to the server and return its replies.
+func TestSaveAndLoad(t *testing.T) {
+	communication := createCommunicationProtocol()
+	saveErr := errors.New("simulated save error")
+
+	go func() {
+		request, _ := communication.WaitForPlayer(context.Background())
+		communication.SaveResponse(context.Background(), SavedGame{Version: 42}, nil)
+
+		request, _ = communication.WaitForPlayer(context.Background())
+		communication.LoadResponse(context.Background(), request.Save.State, nil)
+
+		communication.WaitForPlayer(context.Background())
+		communication.SaveResponse(context.Background(), SavedGame{}, saveErr)
+	}()
+
+	save, err := communication.Save(context.Background())
+	if err != nil || save.Version != 42 {
+		t.Errorf("Expected Save to return the server's saved game. Received: %+v, %v", save, err)
+	}
+
+	state, err := communication.Load(context.Background(), SavedGame{State: GameState{Round: 12}})
+	if err != nil || state.Round != 12 {
+		t.Errorf("Expected Load to return the resumed state. Received: %+v, %v", state, err)
+	}
+
+	if _, err := communication.Save(context.Background()); !errors.Is(err, saveErr) {
+		t.Errorf("Expected Save to return the server's error. Received: %v", err)
+	}
+}
+
+// TestQuit ensures that quitting unblocks every pending and future operation on both
+// sides of the protocol, and that Quit can safely be called more than once.
+func TestQuit(t *testing.T) {
+	communication := createCommunicationProtocol()
+
+	done := make(chan error)
+	go func() {
+		_, err := communication.WaitForPlayer(context.Background())
+		done <- err
+	}()
+
+	communication.Quit()
+	communication.Quit()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrQuit) {
+			t.Errorf("Expected WaitForPlayer to return ErrQuit. Received: %v", err)
+		}
 	case <-time.After(time.Second * 3):
-		t.Errorf("Timed out waiting for hitSignal")
+		t.Fatal("Timed out waiting for WaitForPlayer to give up.")
+	}
+
+	if _, err := communication.Hit(context.Background()); !errors.Is(err, ErrQuit) {
+		t.Errorf("Expected Hit to return ErrQuit. Received: %v", err)
+	}
+
+	if err := communication.StingResponse(context.Background(), Event{}); !errors.Is(err, ErrQuit) {
+		t.Errorf("Expected StingResponse to return ErrQuit. Received: %v", err)
+	}
+}
+
+// TestContextCancellation ensures that a cancelled context unblocks protocol operations.
+func TestContextCancellation(t *testing.T) {
+	communication := createCommunicationProtocol()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := communication.WaitForCPU(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected WaitForCPU to return context.Canceled. Received: %v", err)
+	}
+
+	if err := communication.HitResponse(ctx, Event{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected HitResponse to return context.Canceled. Received: %v", err)
 	}
 }
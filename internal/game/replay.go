@@ -0,0 +1,277 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// replayVersion is the version of the replay file format written by this build.
+const replayVersion = 1
+
+// The commands stored in replay files for the player's moves. Aimed attacks are stored
+// with the key of the bee type they were aimed at, e.g. "hit queen", and items with
+// their key, e.g. "use salve".
+const (
+	hitCommand    = "hit"
+	defendCommand = "defend"
+	useCommand    = "use"
+)
+
+// replayCommand returns the command stored in replay files for the player's move.
+func replayCommand(request Request) string {
+	switch request.Type {
+	case AimRequest:
+		return hitCommand + " " + request.Target.key()
+	case DefendRequest:
+		return defendCommand
+	case UseRequest:
+		return useCommand + " " + request.Item.Key()
+	default:
+		return hitCommand
+	}
+}
+
+// parseReplayCommand returns the request for the player's move a command in a replay
+// file stands for.
+func parseReplayCommand(command string) (Request, error) {
+	switch command {
+	case hitCommand:
+		return Request{Type: HitRequest}, nil
+	case defendCommand:
+		return Request{Type: DefendRequest}, nil
+	}
+
+	if key, ok := strings.CutPrefix(command, hitCommand+" "); ok {
+		if target, ok := ParseBeeType(key); ok {
+			return Request{Type: AimRequest, Target: target}, nil
+		}
+	}
+
+	if key, ok := strings.CutPrefix(command, useCommand+" "); ok {
+		if item, ok := ParseItem(key); ok {
+			return Request{Type: UseRequest, Item: item}, nil
+		}
+	}
+
+	return Request{}, fmt.Errorf("unknown command %q", command)
+}
+
+// Replay is a compact record of a game: the seed and rules it was played with and every
+// command the player gave. Playing the commands back reproduces the game exactly.
+type Replay struct {
+	Version  int        `json:"version"`
+	Seed     uint64     `json:"seed"`
+	Config   GameConfig `json:"config"`
+	Start    *SavedGame `json:"start,omitempty"` // The save the game was resumed from, if the player loaded one.
+	Commands []string   `json:"commands"`
+}
+
+// createReplay starts an empty replay of a game played with the given seed and rules.
+func createReplay(seed uint64, config GameConfig) Replay {
+	return Replay{
+		Version:  replayVersion,
+		Seed:     seed,
+		Config:   config,
+		Commands: []string{},
+	}
+}
+
+// WriteFile stores the replay as compact JSON at path, creating its directory if needed.
+func (replay Replay) WriteFile(path string) error {
+	data, err := json.Marshal(replay)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ReadReplay reads a replay from path and checks that it can be played back.
+func ReadReplay(path string) (Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Replay{}, err
+	}
+
+	var replay Replay
+	if err := json.Unmarshal(data, &replay); err != nil {
+		return Replay{}, fmt.Errorf("invalid replay %s: %w", path, err)
+	}
+
+	if err := replay.validate(); err != nil {
+		return Replay{}, fmt.Errorf("invalid replay %s: %w", path, err)
+	}
+
+	return replay, nil
+}
+
+// validate reports why the replay can't be played back, if it can't.
+func (replay Replay) validate() error {
+	if replay.Version != replayVersion {
+		return fmt.Errorf("unsupported replay version %d, expected %d", replay.Version, replayVersion)
+	}
+
+	if err := replay.Config.Validate(); err != nil {
+		return err
+	}
+
+	if replay.Start != nil {
+		if err := replay.Start.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, command := range replay.Commands {
+		if _, err := parseReplayCommand(command); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Play runs the replay through a fresh game server, passing every event the server sends
+// to handle in order. It stops once the game is finished or the commands run out.
+func (replay Replay) Play(ctx context.Context, handle func(Event)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	communication := StartupServer(ctx, replay.Config, replay.Seed, nil)
+	defer communication.Quit()
+
+	if replay.Start != nil {
+		if _, err := communication.Load(ctx, *replay.Start); err != nil {
+			return err
+		}
+	}
+
+	for _, command := range replay.Commands {
+		request, err := parseReplayCommand(command)
+		if err != nil {
+			return err
+		}
+
+		move := communication.Hit
+		switch request.Type {
+		case AimRequest:
+			move = func(ctx context.Context) (Event, error) {
+				return communication.Aim(ctx, request.Target)
+			}
+		case DefendRequest:
+			move = communication.Defend
+		case UseRequest:
+			move = func(ctx context.Context) (Event, error) {
+				return communication.Use(ctx, request.Item)
+			}
+		}
+
+		for _, receive := range []func(context.Context) (Event, error){move, communication.WaitForCPU} {
+			event, err := receive(ctx)
+
+			// Drones that hatched at the start of the round are sent before the player's move.
+			for err == nil && event.Type == DronesHatched {
+				handle(event)
+
+				event, err = communication.WaitForCPU(ctx)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			handle(event)
+
+			if event.Finished {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// Recorder wraps the protocol a client plays through and records every command the
+// player gives, so the game can be written out as a Replay once it is over.
+type Recorder struct {
+	Protocol
+	replay Replay
+}
+
+// NewRecorder starts recording a game played through protocol with the given seed and rules.
+func NewRecorder(protocol Protocol, seed uint64, config GameConfig) *Recorder {
+	return &Recorder{
+		Protocol: protocol,
+		replay:   createReplay(seed, config),
+	}
+}
+
+// Hit records the player's attack and passes it on to the game.
+func (recorder *Recorder) Hit(ctx context.Context) (Event, error) {
+	event, err := recorder.Protocol.Hit(ctx)
+	if err == nil {
+		recorder.replay.Commands = append(recorder.replay.Commands, replayCommand(Request{Type: HitRequest}))
+	}
+
+	return event, err
+}
+
+// Aim records the player's aimed attack and passes it on to the game. Attacks the game
+// turns down aren't recorded.
+func (recorder *Recorder) Aim(ctx context.Context, target BeeType) (Event, error) {
+	event, err := recorder.Protocol.Aim(ctx, target)
+	if err == nil {
+		recorder.replay.Commands = append(recorder.replay.Commands, replayCommand(Request{Type: AimRequest, Target: target}))
+	}
+
+	return event, err
+}
+
+// Defend records that the player defended and passes it on to the game.
+func (recorder *Recorder) Defend(ctx context.Context) (Event, error) {
+	event, err := recorder.Protocol.Defend(ctx)
+	if err == nil {
+		recorder.replay.Commands = append(recorder.replay.Commands, replayCommand(Request{Type: DefendRequest}))
+	}
+
+	return event, err
+}
+
+// Use records the item the player used and passes it on to the game. Items the game
+// turns down aren't recorded.
+func (recorder *Recorder) Use(ctx context.Context, item Item) (Event, error) {
+	event, err := recorder.Protocol.Use(ctx, item)
+	if err == nil {
+		recorder.replay.Commands = append(recorder.replay.Commands, replayCommand(Request{Type: UseRequest, Item: item}))
+	}
+
+	return event, err
+}
+
+// Load passes the saved game on to the game. Once it has been resumed, the recording
+// starts over from the saved game.
+func (recorder *Recorder) Load(ctx context.Context, save SavedGame) (GameState, error) {
+	state, err := recorder.Protocol.Load(ctx, save)
+	if err == nil {
+		recorder.replay.Start = &save
+		recorder.replay.Commands = []string{}
+	}
+
+	return state, err
+}
+
+// Replay returns the game recorded so far.
+func (recorder *Recorder) Replay() Replay {
+	replay := recorder.replay
+	replay.Commands = slices.Clone(replay.Commands)
+
+	return replay
+}
@@ -0,0 +1,229 @@
+package game
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// recordGame plays a full game with the given seed through a Recorder and returns every
+// event it produced along with the recorder.
+func recordGame(t *testing.T, seed uint64) ([]Event, *Recorder) {
+	return recordConfiguredGame(t, DefaultGameConfig(), seed)
+}
+
+// recordConfiguredGame plays a game by the rules in config like recordGame.
+func recordConfiguredGame(t *testing.T, config GameConfig, seed uint64) ([]Event, *Recorder) {
+	ctx := context.Background()
+	recorder := NewRecorder(StartupServer(ctx, config, seed, nil), seed, config)
+	defer recorder.Quit()
+
+	events := []Event{}
+
+	for {
+		for _, receive := range []func(context.Context) (Event, error){recorder.Hit, recorder.WaitForCPU} {
+			for _, event := range mustEvents(t, ctx, recorder, receive) {
+				events = append(events, event)
+
+				if event.Finished {
+					return events, recorder
+				}
+			}
+		}
+	}
+}
+
+// TestReplayReproducesGame ensures that playing back a recorded game produces the
+// identical event stream.
+func TestReplayReproducesGame(t *testing.T) {
+	original, recorder := recordGame(t, 2024)
+	replay := recorder.Replay()
+
+	if replay.Seed != 2024 || len(replay.Commands) == 0 {
+		t.Fatalf("Recorder did not record the game: %+v", replay)
+	}
+
+	replayed := []Event{}
+	if err := replay.Play(context.Background(), func(event Event) { replayed = append(replayed, event) }); err != nil {
+		t.Fatalf("Unexpected error playing the replay: %s", err)
+	}
+
+	if len(replayed) != len(original) {
+		t.Fatalf("Expected the replay to produce %d events. Received: %d.", len(original), len(replayed))
+	}
+
+	for index := range original {
+		if original[index].Type != replayed[index].Type || original[index].Message != replayed[index].Message || original[index].Outcome != replayed[index].Outcome || original[index].Finished != replayed[index].Finished {
+			t.Fatalf("Event %d differs in the replay. Original: %+v. Replayed: %+v.", index, original[index], replayed[index])
+		}
+	}
+}
+
+// TestReplayPlayerMoves ensures aimed attacks are recorded with their target, alongside
+// defended turns and items used, and played back exactly.
+func TestReplayPlayerMoves(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultGameConfig()
+	recorder := NewRecorder(StartupServer(ctx, config, 7, nil), 7, config)
+	defer recorder.Quit()
+
+	state := NewGameState(config, 7)
+	original := []Event{}
+
+	for finished := false; !finished; {
+		// Aim at whichever type of bee the hive starts with, defending every third round
+		// and putting up smoke in the first.
+		target := state.Hive[0].Type
+		move := func(ctx context.Context) (Event, error) { return recorder.Aim(ctx, target) }
+
+		switch {
+		case state.Round == 0:
+			move = func(ctx context.Context) (Event, error) { return recorder.Use(ctx, SmokeCanister) }
+		case state.Round%3 == 2:
+			move = recorder.Defend
+		}
+
+		for _, receive := range []func(context.Context) (Event, error){move, recorder.WaitForCPU} {
+			for _, event := range mustEvents(t, ctx, recorder, receive) {
+				original = append(original, event)
+				state = event.State
+				finished = event.Finished
+			}
+
+			if finished {
+				break
+			}
+		}
+	}
+
+	replay := recorder.Replay()
+	if replay.Commands[0] != "use smoke" || !strings.HasPrefix(replay.Commands[1], "hit ") || replay.Commands[2] != "defend" {
+		t.Fatalf("Expected the aimed attacks to be recorded with their target alongside the other moves. Received: %v", replay.Commands)
+	}
+
+	replayed := []Event{}
+	if err := replay.Play(ctx, func(event Event) { replayed = append(replayed, event) }); err != nil {
+		t.Fatalf("Unexpected error playing the replay: %s", err)
+	}
+
+	if len(replayed) != len(original) {
+		t.Fatalf("Expected the replay to produce %d events. Received: %d.", len(original), len(replayed))
+	}
+
+	for index := range original {
+		if original[index].Message != replayed[index].Message || original[index].Outcome != replayed[index].Outcome {
+			t.Fatalf("Event %d differs in the replay. Original: %+v. Replayed: %+v.", index, original[index], replayed[index])
+		}
+	}
+}
+
+// TestParseReplayCommand ensures replay commands are turned back into the requests they
+// were recorded from.
+func TestParseReplayCommand(t *testing.T) {
+	for _, request := range []Request{{Type: HitRequest}, {Type: AimRequest, Target: QueenBee}, {Type: AimRequest, Target: DroneBee}, {Type: DefendRequest}, {Type: UseRequest, Item: SmokeCanister}} {
+		parsed, err := parseReplayCommand(replayCommand(request))
+		if err != nil || parsed.Type != request.Type || parsed.Target != request.Target {
+			t.Errorf("Expected %q to be parsed as %+v. Received: %+v, %v", replayCommand(request), request, parsed, err)
+		}
+	}
+
+	for _, command := range []string{"", "hit wasp", "hit queen drone", "defend queen", "use honey"} {
+		if _, err := parseReplayCommand(command); err == nil {
+			t.Errorf("Expected %q to be rejected.", command)
+		}
+	}
+}
+
+// TestRecorderLoad ensures that loading a saved game restarts the recording from the save.
+func TestRecorderLoad(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultGameConfig()
+	recorder := NewRecorder(StartupServer(ctx, config, 3, nil), 3, config)
+	defer recorder.Quit()
+
+	mustEvent(t)(recorder.Hit(ctx))
+	mustEvent(t)(recorder.WaitForCPU(ctx))
+
+	save, err := recorder.Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mustEvent(t)(recorder.Hit(ctx))
+	mustEvent(t)(recorder.WaitForCPU(ctx))
+
+	if _, err := recorder.Load(ctx, save); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := mustEvent(t)(recorder.Hit(ctx))
+	replay := recorder.Replay()
+
+	if replay.Start == nil || replay.Start.State.Round != 2 || len(replay.Commands) != 1 {
+		t.Fatalf("Expected the recording to start over from the save. Replay: %+v", replay)
+	}
+
+	var received Event
+	replay.Play(ctx, func(event Event) {
+		if received.Message == "" {
+			received = event
+		}
+	})
+
+	if received.Message != expected.Message || received.Outcome != expected.Outcome {
+		t.Errorf("Expected the replay to resume from the save. Expected: %+v. Received: %+v.", expected, received)
+	}
+}
+
+// TestReplayFile ensures replays survive being written to and read from disk, and that
+// replays which can't be played back are rejected.
+func TestReplayFile(t *testing.T) {
+	_, recorder := recordGame(t, 77)
+	replay := recorder.Replay()
+
+	path := filepath.Join(t.TempDir(), "replays", "game.json")
+	if err := replay.WriteFile(path); err != nil {
+		t.Fatalf("Unexpected error writing replay: %s", err)
+	}
+
+	loaded, err := ReadReplay(path)
+	if err != nil {
+		t.Fatalf("Unexpected error reading replay: %s", err)
+	}
+
+	if loaded.Seed != replay.Seed || strings.Join(loaded.Commands, ",") != strings.Join(replay.Commands, ",") || loaded.Config.Bees[WorkerBee] != replay.Config.Bees[WorkerBee] {
+		t.Errorf("Replay changed on the way through the file. Expected: %+v. Received: %+v.", replay, loaded)
+	}
+
+	scenarios := []struct {
+		modify      func(replay *Replay)
+		expectedErr string
+	}{
+		{func(replay *Replay) { replay.Version = 0 }, "unsupported replay version"},
+		{func(replay *Replay) { replay.Commands = append(replay.Commands, "dance") }, `unknown command "dance"`},
+		{func(replay *Replay) { replay.Config.PlayerHealth = -1 }, "player.health must be > 0"},
+	}
+
+	for _, scenario := range scenarios {
+		broken := recorder.Replay()
+		scenario.modify(&broken)
+
+		if err := broken.WriteFile(path); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadReplay(path); err == nil || !strings.Contains(err.Error(), scenario.expectedErr) {
+			t.Errorf("Expected error to contain \"%s\". Received: %v", scenario.expectedErr, err)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadReplay(path); err == nil {
+		t.Error("Expected an error reading a malformed replay.")
+	}
+}
@@ -0,0 +1,313 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// rulesetFile mirrors the layout of a ruleset file: one table per section ("player",
+// "loadout", "hive" or the key of a bee type such as "worker") holding integer settings.
+type rulesetFile map[string]map[string]int
+
+const (
+	playerSection  = "player"
+	loadoutSection = "loadout" // How many of each item, by key, the player starts with.
+	hiveSection    = "hive"    // How the hive grows while the game is played.
+)
+
+var (
+	playerSettings = []string{"health", "miss_chance"}
+	beeSettings    = []string{"health", "damage_taken", "damage_dealt", "miss_chance", "count"}
+
+	// Settings added since rulesets were introduced are optional so that older ruleset
+	// files keep loading. Any that are left out take their value from DefaultGameConfig,
+	// and so do the loadout and the hive sections.
+	optionalPlayerSettings = []string{"crit_chance", "damage_spread", "defense", "effect_chance"}
+	optionalBeeSettings    = []string{"damage_spread", "crit_chance", "aim_miss_chance", "drop_chance", "effect_chance", "ability_chance"}
+	hiveSettings           = []string{"spawn_interval", "spawn_count", "spawn_cap"}
+)
+
+// LoadRuleset reads a ruleset from a JSON (.json) or TOML (.toml) file and returns the
+// GameConfig it describes. The file must describe the player and every bee type, and
+// any unknown, missing or invalid setting is reported as an error. Settings that were
+// added to the game later may be left out, in which case the standard game's are used.
+func LoadRuleset(path string) (GameConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return GameConfig{}, err
+	}
+
+	config, err := parseRuleset(data, strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return GameConfig{}, fmt.Errorf("invalid ruleset %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// parseRuleset decodes a ruleset in the given format ("json" or "toml") and validates
+// the GameConfig it describes.
+func parseRuleset(data []byte, format string) (GameConfig, error) {
+	file := rulesetFile{}
+
+	var err error
+	switch strings.ToLower(format) {
+	case "json":
+		err = json.Unmarshal(data, &file)
+	case "toml":
+		err = toml.Unmarshal(data, &file)
+	default:
+		return GameConfig{}, fmt.Errorf("unsupported ruleset format %q, expected json or toml", format)
+	}
+
+	if err != nil {
+		return GameConfig{}, err
+	}
+
+	config, err := file.config()
+	if err != nil {
+		return GameConfig{}, err
+	}
+
+	return config, config.Validate()
+}
+
+// MarshalRuleset encodes the config as a ruleset file in the given format ("json" or
+// "toml"), ready to be loaded with LoadRuleset.
+func (config GameConfig) MarshalRuleset(format string) ([]byte, error) {
+	file := createRulesetFile(config)
+
+	switch strings.ToLower(format) {
+	case "json":
+		return json.MarshalIndent(file, "", "  ")
+	case "toml":
+		var buffer bytes.Buffer
+
+		encoder := toml.NewEncoder(&buffer)
+		encoder.Indent = ""
+
+		err := encoder.Encode(file)
+
+		return buffer.Bytes(), err
+	default:
+		return nil, fmt.Errorf("unsupported ruleset format %q, expected json or toml", format)
+	}
+}
+
+// Setting returns the value of a setting named the way it appears in ruleset files,
+// e.g. "drone.health".
+func (config GameConfig) Setting(name string) (int, error) {
+	section, setting, _ := strings.Cut(name, ".")
+
+	value, ok := createRulesetFile(config)[section][setting]
+	if !ok {
+		return 0, fmt.Errorf("unknown setting %s", name)
+	}
+
+	return value, nil
+}
+
+// WithSetting returns a copy of the config with a setting named the way it appears in
+// ruleset files changed to value. The config is validated after the change.
+func (config GameConfig) WithSetting(name string, value int) (GameConfig, error) {
+	if _, err := config.Setting(name); err != nil {
+		return GameConfig{}, err
+	}
+
+	section, setting, _ := strings.Cut(name, ".")
+
+	file := createRulesetFile(config)
+	file[section][setting] = value
+
+	changed, err := file.config()
+	if err != nil {
+		return GameConfig{}, err
+	}
+
+	return changed, changed.Validate()
+}
+
+// createRulesetFile lays the config out the way it is written in a ruleset file.
+func createRulesetFile(config GameConfig) rulesetFile {
+	file := rulesetFile{
+		playerSection: {
+			"health":        config.PlayerHealth,
+			"miss_chance":   int(config.PlayerMissChance),
+			"crit_chance":   int(config.PlayerCritChance),
+			"damage_spread": int(config.PlayerDamageSpread),
+			"defense":       int(config.PlayerDefense),
+			"effect_chance": int(config.PlayerEffectChance),
+		},
+		loadoutSection: {},
+		hiveSection: {
+			"spawn_interval": int(config.SpawnInterval),
+			"spawn_count":    int(config.SpawnCount),
+			"spawn_cap":      int(config.SpawnCap),
+		},
+	}
+
+	for item := range itemDefinitions {
+		file[loadoutSection][item.Key()] = int(config.Loadout[item])
+	}
+
+	for beeType, stats := range config.Bees {
+		file[beeType.key()] = map[string]int{
+			"health":          stats.Health,
+			"damage_taken":    stats.DamageTaken,
+			"damage_dealt":    stats.DamageDealt,
+			"damage_spread":   int(stats.DamageSpread),
+			"miss_chance":     int(stats.MissChance),
+			"crit_chance":     int(stats.CritChance),
+			"aim_miss_chance": int(stats.AimMissChance),
+			"drop_chance":     int(stats.DropChance),
+			"effect_chance":   int(stats.EffectChance),
+			"ability_chance":  int(stats.AbilityChance),
+			"count":           int(stats.Count),
+		}
+	}
+
+	return file
+}
+
+// config converts the file into a GameConfig. It reports unknown or missing sections
+// and settings, as well as negative values for settings that can't be negative.
+func (file rulesetFile) config() (GameConfig, error) {
+	var errs []error
+
+	beeTypesByKey := map[string]BeeType{}
+	for beeType, definition := range beeDefinitions {
+		beeTypesByKey[definition.key] = beeType
+	}
+
+	for _, section := range slices.Sorted(maps.Keys(file)) {
+		if _, ok := beeTypesByKey[section]; section != playerSection && section != loadoutSection && section != hiveSection && !ok {
+			errs = append(errs, fmt.Errorf("unknown section %q", section))
+		}
+	}
+
+	items := slices.Sorted(maps.Keys(itemDefinitions))
+
+	itemKeys := make([]string, len(items))
+	for index, item := range items {
+		itemKeys[index] = item.Key()
+	}
+
+	errs = append(errs, file.checkSettings(playerSection, playerSettings, optionalPlayerSettings)...)
+	errs = append(errs, file.checkSettings(loadoutSection, nil, itemKeys)...)
+	errs = append(errs, file.checkSettings(hiveSection, nil, hiveSettings)...)
+
+	for _, key := range slices.Sorted(maps.Keys(beeTypesByKey)) {
+		errs = append(errs, file.checkSettings(key, beeSettings, optionalBeeSettings)...)
+	}
+
+	// Rulesets written before the queen laid eggs leave out the [hive] section, and are
+	// played as they were then, without any.
+	if _, ok := file[hiveSection]; !ok {
+		file[hiveSection] = map[string]int{"spawn_interval": 0, "spawn_count": 0, "spawn_cap": 0}
+	}
+
+	file.fillDefaults(createRulesetFile(DefaultGameConfig()))
+
+	config := GameConfig{
+		PlayerHealth:       file[playerSection]["health"],
+		PlayerMissChance:   file.unsigned(playerSection, "miss_chance", &errs),
+		PlayerCritChance:   file.unsigned(playerSection, "crit_chance", &errs),
+		PlayerDamageSpread: file.unsigned(playerSection, "damage_spread", &errs),
+		PlayerDefense:      file.unsigned(playerSection, "defense", &errs),
+		PlayerEffectChance: file.unsigned(playerSection, "effect_chance", &errs),
+		Loadout:            map[Item]uint{},
+		Bees:               map[BeeType]BeeStats{},
+	}
+
+	for _, item := range items {
+		if count := file.unsigned(loadoutSection, item.Key(), &errs); count > 0 {
+			config.Loadout[item] = count
+		}
+	}
+
+	config.SpawnInterval = file.unsigned(hiveSection, "spawn_interval", &errs)
+	config.SpawnCount = file.unsigned(hiveSection, "spawn_count", &errs)
+	config.SpawnCap = file.unsigned(hiveSection, "spawn_cap", &errs)
+
+	for _, key := range slices.Sorted(maps.Keys(beeTypesByKey)) {
+		config.Bees[beeTypesByKey[key]] = BeeStats{
+			Health:        file[key]["health"],
+			DamageTaken:   file[key]["damage_taken"],
+			DamageDealt:   file[key]["damage_dealt"],
+			DamageSpread:  file.unsigned(key, "damage_spread", &errs),
+			MissChance:    file.unsigned(key, "miss_chance", &errs),
+			CritChance:    file.unsigned(key, "crit_chance", &errs),
+			AimMissChance: file.unsigned(key, "aim_miss_chance", &errs),
+			DropChance:    file.unsigned(key, "drop_chance", &errs),
+			EffectChance:  file.unsigned(key, "effect_chance", &errs),
+			AbilityChance: file.unsigned(key, "ability_chance", &errs),
+			Count:         file.unsigned(key, "count", &errs),
+		}
+	}
+
+	return config, errors.Join(errs...)
+}
+
+// checkSettings reports a missing section, missing required settings and unknown
+// settings. A section with no required settings may be left out.
+func (file rulesetFile) checkSettings(section string, required, optional []string) []error {
+	values, ok := file[section]
+	if !ok {
+		if len(required) == 0 {
+			return nil
+		}
+
+		return []error{fmt.Errorf("missing section %q", section)}
+	}
+
+	var errs []error
+
+	for _, setting := range required {
+		if _, ok := values[setting]; !ok {
+			errs = append(errs, fmt.Errorf("%s.%s is required", section, setting))
+		}
+	}
+
+	for _, setting := range slices.Sorted(maps.Keys(values)) {
+		if !slices.Contains(required, setting) && !slices.Contains(optional, setting) {
+			errs = append(errs, fmt.Errorf("unknown setting %s.%s", section, setting))
+		}
+	}
+
+	return errs
+}
+
+// fillDefaults copies every setting the file leaves out from defaults.
+func (file rulesetFile) fillDefaults(defaults rulesetFile) {
+	for section, settings := range defaults {
+		if file[section] == nil {
+			file[section] = map[string]int{}
+		}
+
+		for setting, value := range settings {
+			if _, ok := file[section][setting]; !ok {
+				file[section][setting] = value
+			}
+		}
+	}
+}
+
+// unsigned returns a setting that can't be negative, recording an error if it is.
+func (file rulesetFile) unsigned(section, setting string, errs *[]error) uint {
+	value := file[section][setting]
+	if value < 0 {
+		*errs = append(*errs, fmt.Errorf("%s.%s must not be negative", section, setting))
+		return 0
+	}
+
+	return uint(value)
+}
@@ -0,0 +1,270 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testRulesetJSON = `{
+	"player": {"health": 120, "miss_chance": 5, "crit_chance": 15, "damage_spread": 20, "defense": 40, "effect_chance": 35},
+	"loadout": {"salve": 2, "smoke": 0, "antihistamine": 1},
+	"hive": {"spawn_interval": 4, "spawn_count": 2, "spawn_cap": 12},
+	"queen": {"health": 100, "damage_taken": 10, "damage_dealt": 10, "damage_spread": 0, "miss_chance": 10, "crit_chance": 0, "aim_miss_chance": 60, "drop_chance": 50, "effect_chance": 0, "ability_chance": 20, "count": 1},
+	"worker": {"health": 75, "damage_taken": 25, "damage_dealt": 5, "damage_spread": 0, "miss_chance": 15, "crit_chance": 0, "aim_miss_chance": 25, "drop_chance": 20, "effect_chance": 0, "ability_chance": 0, "count": 3},
+	"drone": {"health": 40, "damage_taken": 30, "damage_dealt": 2, "damage_spread": 50, "miss_chance": 20, "crit_chance": 5, "aim_miss_chance": 15, "drop_chance": 10, "effect_chance": 25, "ability_chance": 30, "count": 10}
+}`
+
+// TestParseRuleset ensures that JSON and TOML rulesets are decoded into the expected config.
+func TestParseRuleset(t *testing.T) {
+	config, err := parseRuleset([]byte(testRulesetJSON), "json")
+	if err != nil {
+		t.Fatalf("Unexpected error parsing JSON ruleset: %s", err)
+	}
+
+	if config.PlayerHealth != 120 || config.PlayerMissChance != 5 || config.PlayerCritChance != 15 || config.PlayerDamageSpread != 20 || config.PlayerDefense != 40 || config.PlayerEffectChance != 35 {
+		t.Errorf("JSON ruleset gave incorrect player settings: %+v", config)
+	}
+
+	if config.SpawnInterval != 4 || config.SpawnCount != 2 || config.SpawnCap != 12 {
+		t.Errorf("JSON ruleset gave incorrect hive settings: %+v", config)
+	}
+
+	expectedLoadout := map[Item]uint{HealingSalve: 2, Antihistamine: 1}
+	if !reflect.DeepEqual(config.Loadout, expectedLoadout) {
+		t.Errorf("JSON ruleset gave an incorrect loadout. Expected: %v. Received: %v.", expectedLoadout, config.Loadout)
+	}
+
+	expectedDrone := BeeStats{Health: 40, DamageTaken: 30, DamageDealt: 2, DamageSpread: 50, MissChance: 20, CritChance: 5, AimMissChance: 15, DropChance: 10, EffectChance: 25, AbilityChance: 30, Count: 10}
+	if config.Bees[DroneBee] != expectedDrone {
+		t.Errorf("JSON ruleset gave incorrect drone stats. Expected: %+v. Received: %+v.", expectedDrone, config.Bees[DroneBee])
+	}
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "rules", "default.toml"))
+	if err != nil {
+		t.Fatalf("Failed to read the default ruleset: %s", err)
+	}
+
+	config, err = parseRuleset(data, "toml")
+	if err != nil {
+		t.Fatalf("Unexpected error parsing TOML ruleset: %s", err)
+	}
+
+	defaults := DefaultGameConfig()
+	if config.PlayerHealth != defaults.PlayerHealth || config.PlayerMissChance != defaults.PlayerMissChance || config.PlayerDefense != defaults.PlayerDefense {
+		t.Errorf("Default TOML ruleset gave incorrect player settings: %+v", config)
+	}
+
+	if config.SpawnInterval != defaults.SpawnInterval || config.SpawnCount != defaults.SpawnCount || config.SpawnCap != defaults.SpawnCap {
+		t.Errorf("Default TOML ruleset gave incorrect hive settings: %+v", config)
+	}
+
+	if !reflect.DeepEqual(config.Loadout, defaults.Loadout) {
+		t.Errorf("Default TOML ruleset gave an incorrect loadout: %v", config.Loadout)
+	}
+
+	for beeType, stats := range defaults.Bees {
+		if config.Bees[beeType] != stats {
+			t.Errorf("Default TOML ruleset gave incorrect stats for the %s. Expected: %+v. Received: %+v.", beeType, stats, config.Bees[beeType])
+		}
+	}
+}
+
+// TestParseRulesetDefaults ensures a ruleset written before the later settings were added
+// still loads, with the standard game's values for everything it leaves out.
+func TestParseRulesetDefaults(t *testing.T) {
+	ruleset := `
+[player]
+health = 150
+miss_chance = 5
+
+[queen]
+health = 100
+damage_taken = 10
+damage_dealt = 10
+miss_chance = 10
+count = 1
+
+[worker]
+health = 75
+damage_taken = 25
+damage_dealt = 5
+miss_chance = 15
+count = 5
+
+[drone]
+health = 60
+damage_taken = 30
+damage_dealt = 1
+miss_chance = 20
+count = 10
+`
+
+	config, err := parseRuleset([]byte(ruleset), "toml")
+	if err != nil {
+		t.Fatalf("Unexpected error parsing an older ruleset: %s", err)
+	}
+
+	expected := DefaultGameConfig()
+	expected.PlayerHealth = 150
+	expected.PlayerMissChance = 5
+
+	drone := expected.Bees[DroneBee]
+	drone.Count = 10
+	expected.Bees[DroneBee] = drone
+
+	// The ruleset has no [hive] section, so the queen lays no eggs, as before there was one.
+	expected.SpawnInterval, expected.SpawnCount, expected.SpawnCap = 0, 0, 0
+
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("Expected the missing settings to come from the standard game. Expected: %+v. Received: %+v.", expected, config)
+	}
+
+	partial := strings.Replace(testRulesetJSON, `"spawn_interval": 4, `, "", 1)
+	partial = strings.Replace(partial, `"loadout": {"salve": 2, "smoke": 0, "antihistamine": 1},`, `"loadout": {"salve": 2},`, 1)
+
+	if config, err = parseRuleset([]byte(partial), "json"); err != nil {
+		t.Fatalf("Unexpected error parsing a partial ruleset: %s", err)
+	}
+
+	standard := DefaultGameConfig()
+	if config.SpawnInterval != standard.SpawnInterval || config.SpawnCount != 2 || config.Loadout[HealingSalve] != 2 || config.Loadout[SmokeCanister] != standard.Loadout[SmokeCanister] {
+		t.Errorf("Expected the partial sections to be completed from the standard game. Received: %+v", config)
+	}
+}
+
+// TestParseRulesetErrors ensures invalid rulesets are rejected with messages that name
+// the offending setting.
+func TestParseRulesetErrors(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		ruleset     string
+		format      string
+		expectedErr string
+	}{
+		{"zero count", strings.Replace(testRulesetJSON, `"count": 3`, `"count": 0`, 1), "json", "worker.count must be > 0"},
+		{"negative count", strings.Replace(testRulesetJSON, `"count": 3`, `"count": -3`, 1), "json", "worker.count must not be negative"},
+		{"miss chance too high", strings.Replace(testRulesetJSON, `"miss_chance": 5`, `"miss_chance": 150`, 1), "json", "player.miss_chance must be between 0 and 100"},
+		{"zero health", strings.Replace(testRulesetJSON, `"health": 40`, `"health": 0`, 1), "json", "drone.health must be > 0"},
+		{"missing setting", strings.Replace(testRulesetJSON, `"damage_dealt": 5, `, "", 1), "json", "worker.damage_dealt is required"},
+		{"crit chance too high", strings.Replace(testRulesetJSON, `"crit_chance": 15`, `"crit_chance": 101`, 1), "json", "player.crit_chance must be between 0 and 100"},
+		{"ability chance too high", strings.Replace(testRulesetJSON, `"ability_chance": 30`, `"ability_chance": 120`, 1), "json", "drone.ability_chance must be between 0 and 100"},
+		{"negative spawn cap", strings.Replace(testRulesetJSON, `"spawn_cap": 12`, `"spawn_cap": -1`, 1), "json", "hive.spawn_cap must not be negative"},
+		{"spawning without drones", strings.Replace(testRulesetJSON, `"spawn_count": 2`, `"spawn_count": 0`, 1), "json", "hive.spawn_count must be > 0 while hive.spawn_interval is set"},
+		{"unknown optional setting", strings.Replace(testRulesetJSON, `"spawn_count": 2`, `"spawn_rate": 2`, 1), "json", "unknown setting hive.spawn_rate"},
+		{"unknown setting", strings.Replace(testRulesetJSON, `"count": 1}`, `"count": 1, "speed": 3}`, 1), "json", "unknown setting queen.speed"},
+		{"unknown section", strings.Replace(testRulesetJSON, `"player"`, `"hornet": {}, "player"`, 1), "json", `unknown section "hornet"`},
+		{"missing section", "[player]\nhealth = 100\nmiss_chance = 10\n", "toml", `missing section "queen"`},
+		{"non-integer value", strings.Replace(testRulesetJSON, `"health": 120`, `"health": 12.5`, 1), "json", "cannot unmarshal"},
+		{"unsupported format", testRulesetJSON, "yaml", `unsupported ruleset format "yaml"`},
+	}
+
+	for _, scenario := range scenarios {
+		_, err := parseRuleset([]byte(scenario.ruleset), scenario.format)
+
+		if err == nil {
+			t.Errorf("%s: expected an error but ruleset was accepted.", scenario.name)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), scenario.expectedErr) {
+			t.Errorf("%s: expected error to contain \"%s\". Received: \"%s\"", scenario.name, scenario.expectedErr, err)
+		}
+	}
+}
+
+// TestLoadRuleset ensures rulesets are read from disk and errors mention the file.
+func TestLoadRuleset(t *testing.T) {
+	directory := t.TempDir()
+
+	validPath := filepath.Join(directory, "rules.json")
+	if err := os.WriteFile(validPath, []byte(testRulesetJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadRuleset(validPath); err != nil {
+		t.Errorf("Unexpected error loading ruleset: %s", err)
+	}
+
+	invalidPath := filepath.Join(directory, "broken.json")
+	if err := os.WriteFile(invalidPath, []byte(strings.Replace(testRulesetJSON, `"count": 3`, `"count": 0`, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadRuleset(invalidPath); err == nil || !strings.Contains(err.Error(), invalidPath) {
+		t.Errorf("Expected error to mention the ruleset path. Received: %v", err)
+	}
+
+	if _, err := LoadRuleset(filepath.Join(directory, "missing.toml")); err == nil {
+		t.Error("Expected an error loading a ruleset that does not exist.")
+	}
+}
+
+// TestMarshalRuleset ensures a config written as a ruleset reads back as the same config.
+func TestMarshalRuleset(t *testing.T) {
+	config, err := DefaultGameConfig().WithSetting("drone.health", 45)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{"json", "toml"} {
+		data, err := config.MarshalRuleset(format)
+		if err != nil {
+			t.Fatalf("Unexpected error marshalling %s: %s", format, err)
+		}
+
+		parsed, err := parseRuleset(data, format)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %s: %s", format, err)
+		}
+
+		if !reflect.DeepEqual(parsed, config) {
+			t.Errorf("Expected the %s ruleset to read back unchanged. Expected: %+v. Received: %+v.", format, config, parsed)
+		}
+	}
+
+	if _, err := config.MarshalRuleset("yaml"); err == nil {
+		t.Error("Expected an error for an unsupported format.")
+	}
+}
+
+// TestSettings ensures settings can be read and changed by their ruleset names.
+func TestSettings(t *testing.T) {
+	config := DefaultGameConfig()
+
+	changed, err := config.WithSetting("player.miss_chance", 25)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if value, err := changed.Setting("player.miss_chance"); err != nil || value != 25 || changed.PlayerMissChance != 25 {
+		t.Errorf("Expected player.miss_chance to be 25. Received: %d, %v", value, err)
+	}
+
+	if config.PlayerMissChance != 10 {
+		t.Error("Expected WithSetting to leave the original config alone.")
+	}
+
+	if value, err := config.Setting("worker.count"); err != nil || value != 5 {
+		t.Errorf("Expected worker.count to be 5. Received: %d, %v", value, err)
+	}
+
+	scenarios := []struct {
+		setting     string
+		value       int
+		expectedErr string
+	}{
+		{"wasp.health", 10, "unknown setting wasp.health"},
+		{"drone.speed", 10, "unknown setting drone.speed"},
+		{"worker.count", 0, "worker.count must be > 0"},
+		{"drone.miss_chance", -1, "drone.miss_chance must not be negative"},
+	}
+
+	for _, scenario := range scenarios {
+		if _, err := config.WithSetting(scenario.setting, scenario.value); err == nil || !strings.Contains(err.Error(), scenario.expectedErr) {
+			t.Errorf("Expected error to contain \"%s\". Received: %v", scenario.expectedErr, err)
+		}
+	}
+}
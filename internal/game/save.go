@@ -0,0 +1,88 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+)
+
+// saveVersion is the version of the save file format written by this build.
+// It must be bumped whenever SavedGame changes in a way older builds can't read.
+const saveVersion = 1
+
+// SavedGame is everything needed to resume a game exactly where it was left: the rules
+// it is played by, its state and the state of its random number generator.
+type SavedGame struct {
+	Version int        `json:"version"`
+	Config  GameConfig `json:"config"`
+	State   GameState  `json:"state"`
+	Random  []byte     `json:"random"` // Binary state of the game's PCG random source.
+}
+
+// WriteFile stores the saved game as JSON at path, creating its directory if needed.
+func (save SavedGame) WriteFile(path string) error {
+	data, err := json.MarshalIndent(save, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ReadSavedGame reads a saved game from path and checks that it can be resumed.
+func ReadSavedGame(path string) (SavedGame, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SavedGame{}, err
+	}
+
+	var save SavedGame
+	if err := json.Unmarshal(data, &save); err != nil {
+		return SavedGame{}, fmt.Errorf("invalid save %s: %w", path, err)
+	}
+
+	if err := save.validate(); err != nil {
+		return SavedGame{}, fmt.Errorf("invalid save %s: %w", path, err)
+	}
+
+	return save, nil
+}
+
+// validate reports why the saved game can't be resumed, if it can't.
+func (save SavedGame) validate() error {
+	if save.Version != saveVersion {
+		return fmt.Errorf("unsupported save version %d, expected %d", save.Version, saveVersion)
+	}
+
+	if err := save.Config.Validate(); err != nil {
+		return err
+	}
+
+	if save.State.Player.Health <= 0 || len(save.State.Hive) == 0 {
+		return errors.New("the saved game is already over")
+	}
+
+	if _, err := save.source(); err != nil {
+		return fmt.Errorf("corrupt random state: %w", err)
+	}
+
+	return nil
+}
+
+// source restores the random source the game was saved with.
+func (save SavedGame) source() (*rand.PCG, error) {
+	source := &rand.PCG{}
+
+	if err := source.UnmarshalBinary(save.Random); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testSavedGame returns a valid saved game at the start of a standard game.
+func testSavedGame(t *testing.T) SavedGame {
+	random, err := rand.NewPCG(1, 2).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultGameConfig()
+
+	return SavedGame{
+		Version: saveVersion,
+		Config:  config,
+		State: GameState{
+			Seed:   1,
+			Round:  3,
+			Player: createPlayer(config),
+			Hive:   createHive(config),
+		},
+		Random: random,
+	}
+}
+
+// TestSavedGameRoundTrip ensures a saved game is read back exactly as it was written.
+func TestSavedGameRoundTrip(t *testing.T) {
+	save := testSavedGame(t)
+	save.State.Journal = []JournalEntry{createJournalEntry(1, BeeHit, Outcome{BeeType: DroneBee, Damage: 30})}
+
+	path := filepath.Join(t.TempDir(), "nested", "game.json")
+
+	if err := save.WriteFile(path); err != nil {
+		t.Fatalf("Unexpected error writing save: %s", err)
+	}
+
+	loaded, err := ReadSavedGame(path)
+	if err != nil {
+		t.Fatalf("Unexpected error reading save: %s", err)
+	}
+
+	if loaded.State.Round != save.State.Round || loaded.State.Seed != save.State.Seed || len(loaded.State.Hive) != len(save.State.Hive) {
+		t.Errorf("Saved state changed on the way through the file. Expected: %+v. Received: %+v.", save.State, loaded.State)
+	}
+
+	if len(loaded.State.Journal) != 1 || loaded.State.Journal[0] != save.State.Journal[0] {
+		t.Errorf("Saved journal changed on the way through the file: %+v", loaded.State.Journal)
+	}
+
+	if loaded.Config.Bees[DroneBee] != save.Config.Bees[DroneBee] {
+		t.Errorf("Saved config changed on the way through the file: %+v", loaded.Config)
+	}
+
+	source, err := loaded.source()
+	if err != nil || source.Uint64() != rand.NewPCG(1, 2).Uint64() {
+		t.Errorf("Saved random state changed on the way through the file.")
+	}
+}
+
+// TestReadSavedGameErrors ensures saves that can't be resumed are rejected.
+func TestReadSavedGameErrors(t *testing.T) {
+	scenarios := []struct {
+		modify      func(save *SavedGame)
+		expectedErr string
+	}{
+		{func(save *SavedGame) { save.Version = saveVersion + 1 }, "unsupported save version"},
+		{func(save *SavedGame) { save.Config.PlayerHealth = 0 }, "player.health must be > 0"},
+		{func(save *SavedGame) { save.State.Player.Health = 0 }, "already over"},
+		{func(save *SavedGame) { save.Random = []byte("garbage") }, "corrupt random state"},
+	}
+
+	for _, scenario := range scenarios {
+		save := testSavedGame(t)
+		scenario.modify(&save)
+
+		path := filepath.Join(t.TempDir(), "game.json")
+		if err := save.WriteFile(path); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ReadSavedGame(path); err == nil || !strings.Contains(err.Error(), scenario.expectedErr) {
+			t.Errorf("Expected error to contain \"%s\". Received: %v", scenario.expectedErr, err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "broken.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadSavedGame(path); err == nil {
+		t.Error("Expected an error reading a malformed save.")
+	}
+}
@@ -1,7 +1,11 @@
 package game
 
 import (
+	"context"
+	"encoding"
+	"errors"
 	"fmt"
+	"maps"
 	"math/rand/v2"
 	"slices"
 )
@@ -9,130 +13,452 @@ import (
 // GameState represents the current status of a running game.
 // It holds information about the player, the hive, and turn statistics.
 type GameState struct {
-	Player Player
-	Hive   []Bee
-	Round  uint
-	Hits   uint
-	Stings uint
+	Seed      uint64
+	Player    Player
+	Hive      []Bee
+	Round     uint
+	Hits      uint
+	Stings    uint
+	Collapsed uint           // Number of bees that died when the hive collapsed after losing its queen.
+	Commanded bool           // Whether the queen's royal command makes the hive's next sting certain to land.
+	Journal   []JournalEntry // Every action taken so far, in the order it happened.
+}
+
+// Snapshot returns a copy of the state that shares no memory with it, so it stays the
+// same however the game carries on.
+func (state GameState) Snapshot() GameState {
+	state.Player.Inventory = maps.Clone(state.Player.Inventory)
+	state.Hive = slices.Clone(state.Hive)
+	state.Journal = slices.Clone(state.Journal)
+
+	return state
 }
 
 // GameServer manages the lifecycle of the game.
 // It coordinates turns, updates state, and communicates with the client via a protocol.
 type GameServer struct {
 	finished      bool
+	notice        string         // What happened at the start of the round, told to the player with their next event.
+	roundStart    []JournalEntry // What happened at the start of the round, sent with the player's next event.
+	pending       []Event        // Events that happened at the start of the round, sent before the player's move.
 	state         GameState
+	config        GameConfig
+	source        rand.Source
+	random        *rand.Rand
 	communication Protocol
 }
 
+// NewSource returns the random source the game uses for the given seed.
+// Two servers started from sources with the same seed play out identically
+// when given the same commands.
+func NewSource(seed uint64) rand.Source {
+	return rand.NewPCG(seed, seed)
+}
+
 // StartupServer initializes the game server and returns a communication channel for the client.
 // This function also spawns a goroutine to run the main game loop.
-func StartupServer() *CommunicationProtocol {
+//
+// The game is played by the rules in config; use DefaultGameConfig for the standard game.
+// Every random roll the server makes is drawn from source. The seed is recorded in the
+// GameState so that a finished game can be reported and replayed. If source is nil,
+// NewSource(seed) is used.
+//
+// The game loop exits when the game finishes, when either side calls Quit on the
+// protocol, or when ctx is cancelled.
+func StartupServer(ctx context.Context, config GameConfig, seed uint64, source rand.Source) *CommunicationProtocol {
 	communication := createCommunicationProtocol()
 
-	server := &GameServer{
-		finished: false,
-		state: GameState{
-			Round:  0,
-			Hits:   0,
-			Stings: 0,
-			Player: createPlayer(),
-			Hive:   createHive(1, 5, 25),
-		},
-		communication: communication,
+	if source == nil {
+		source = NewSource(seed)
 	}
 
-	go server.run()
+	server := createGameServer(config, seed, source, communication)
+
+	go server.run(ctx)
 
 	return communication
 }
 
+// NewGameState returns the state a game played by the rules in config starts in.
+func NewGameState(config GameConfig, seed uint64) GameState {
+	return GameState{
+		Seed:   seed,
+		Round:  0,
+		Hits:   0,
+		Stings: 0,
+		Player: createPlayer(config),
+		Hive:   createHive(config),
+	}
+}
+
+// createGameServer sets up a new game played by the rules in config that talks to the
+// player through communication.
+func createGameServer(config GameConfig, seed uint64, source rand.Source, communication Protocol) *GameServer {
+	return &GameServer{
+		finished:      false,
+		state:         NewGameState(config, seed),
+		config:        config,
+		source:        source,
+		random:        rand.New(source),
+		communication: communication,
+	}
+}
+
 // run starts the main game loop, alternating turns between the player and the hive.
-func (server *GameServer) run() {
+// Status effects tick at the start of every round. It returns nil once the game is
+// finished, or the protocol's error if the game was quit or cancelled before then.
+func (server *GameServer) run(ctx context.Context) error {
 	for {
 		server.state.Round += 1
+		server.startRound()
 
-		server.playersTurn()
+		if err := server.playersTurn(ctx); err != nil {
+			return err
+		}
 
 		if server.finished {
-			return
+			return nil
 		}
 
-		server.hivesTurn()
+		if err := server.hivesTurn(ctx); err != nil {
+			return err
+		}
 
 		if server.finished {
-			return
+			return nil
+		}
+	}
+}
+
+// startRound ticks the status effects at the start of a round and lets the queen's eggs
+// hatch. Whatever the poison did is recorded in the journal and noted for the player's
+// next event. The drones that hatched are recorded too and kept as an event of their own,
+// sent once the player has decided on their move.
+func (server *GameServer) startRound() {
+	server.notice = ""
+	server.roundStart = nil
+	server.pending = nil
+
+	tick(&server.state, func(eventType EventType, outcome Outcome) {
+		switch {
+		case eventType == PlayerPoisoned:
+			server.note(eventType, outcome, fmt.Sprintf("The poison burns! You lose %d HP. ", outcome.Damage))
+		case outcome.HealthAfter <= 0:
+			server.note(eventType, outcome, fmt.Sprintf("The poison killed the %s. ", outcome.BeeType))
+		default:
+			server.note(eventType, outcome, fmt.Sprintf("The poison burns the %s for %d HP. ", outcome.BeeType, outcome.Damage))
 		}
+	})
+
+	if outcome, hatched := hatch(&server.state, server.config); hatched {
+		msg := fmt.Sprintf("The Queen's eggs hatch into %d new drones!", outcome.Hatched)
+		if outcome.Hatched == 1 {
+			msg = "The Queen's eggs hatch into a new drone!"
+		}
+
+		server.pending = append(server.pending, server.event(DronesHatched, msg, outcome))
 	}
 }
 
+// note records an event of the given type that happened at the start of the round in
+// the journal, and keeps it and its message for the player's next event.
+func (server *GameServer) note(eventType EventType, outcome Outcome, msg string) {
+	server.record(eventType, outcome)
+
+	server.roundStart = append(server.roundStart, server.state.Journal[len(server.state.Journal)-1])
+	server.notice += msg
+}
+
 // playersTurn handles the player's action phase.
-// It waits for input, applies damage to a random bee, and checks for win conditions.
-func (server *GameServer) playersTurn() {
+// It waits for input, applies damage to a random bee or one of the type the player aimed
+// at, and checks for win conditions. A player who defends or uses an item doesn't
+// attack at all, and a stunned player can't do anything. The game may already have been
+// decided by poison at the start of the round, which the player is told once they move.
+// Anything that happened at the start of the round as an event of its own, e.g. drones
+// hatching, is sent before the event describing the player's move.
+func (server *GameServer) playersTurn(ctx context.Context) error {
 	// Wait for the player's input.
-	server.communication.WaitForPlayer()
+	request, err := server.waitForPlayer(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range server.pending {
+		if err := server.communication.HitResponse(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	server.pending = nil
+
+	switch {
+	case server.state.Player.Health <= 0:
+		server.finished = true
+
+		health := server.state.Player.Health
+		outcome := Outcome{Damage: poisonDamage, HealthBefore: health + poisonDamage, HealthAfter: health}
+
+		return server.communication.GameFinishedResponse(ctx, server.describe(PlayerPoisoned, "The poison finished you off!", outcome))
+	case hasDeadQueen(server.state.Hive):
+		return server.collapseHive(ctx)
+	case len(server.state.Hive) == 0:
+		server.finished = true
+
+		return server.communication.GameFinishedResponse(ctx, server.describe(BeePoisoned, "The poison finished off the hive!", Outcome{}))
+	case server.state.Player.Effects.Has(Stunned):
+		eventType, outcome := stunned(&server.state)
+		return server.communication.HitResponse(ctx, server.event(eventType, "You're stunned and can't move this round!", outcome))
+	}
+
+	var eventType EventType
+	var outcome Outcome
+
+	switch request.Type {
+	case DefendRequest:
+		eventType, outcome = defend(&server.state)
+		return server.communication.HitResponse(ctx, server.event(eventType, "You raise your guard and brace for the next sting.", outcome))
+	case UseRequest:
+		eventType, outcome = use(&server.state, server.config, request.Item)
+		return server.communication.HitResponse(ctx, server.event(eventType, request.Item.generateUseMessage(outcome), outcome))
+	case AimRequest:
+		eventType, outcome = aimedAttack(&server.state, server.config, server.random, request.Target)
+	default:
+		eventType, outcome = attack(&server.state, server.config, server.random)
+	}
 
-	// Get a reference to a random bee from the hive.
-	beeIndex := rand.IntN(len(server.state.Hive))
-	selectedBee := &server.state.Hive[beeIndex]
+	if eventType == PlayerMissed {
+		msg := "Miss! You just missed the hive, better luck next time!"
+		if outcome.Aimed {
+			msg = fmt.Sprintf("Miss! The %s dodged your aim, better luck next time!", outcome.BeeType)
+		}
+
+		return server.communication.HitResponse(ctx, server.event(PlayerMissed, msg, outcome))
+	}
 
-	// Check to see if player misses their shot.
-	if rand.UintN(101) < server.state.Player.MissChance {
-		server.communication.HitResponse("Miss! You just missed the hive, better luck next time!", server.state)
-		return
+	// Generate a witty message about the bee that was hit.
+	hitBee := Bee{Type: outcome.BeeType, Health: outcome.HealthAfter}
+	hitMsg := hitBee.generateHitMessage(outcome.Damage, eventType != BeeHit, outcome.Critical)
+	if outcome.ItemDropped {
+		hitMsg += fmt.Sprintf(" You picked up the %s it dropped.", outcome.Item)
 	}
 
-	// Player managed to successfully hit a bee. Increment counter.
-	server.state.Hits += 1
+	if outcome.EffectInflicted {
+		hitMsg += fmt.Sprintf(" The %s is now %s!", outcome.BeeType, outcome.Effect)
+	}
 
-	// Determine the damage the bee took and generate a witty message.
-	beeDied := selectedBee.takeDamage()
-	hitMsg := selectedBee.generateHitMessage(beeDied)
+	switch {
+	case eventType == QueenKilled:
+		// The last queen died, so the rest of the hive dies with her and the player won.
+		if err := server.communication.HitResponse(ctx, server.event(QueenKilled, hitMsg, outcome)); err != nil {
+			return err
+		}
 
-	// If the queen died the player won.
-	if beeDied && selectedBee.Type == QueenBee {
+		return server.collapseHive(ctx)
+	case len(server.state.Hive) == 0:
+		// A hive without a queen is won once the last bee has been killed.
 		server.finished = true
 
-		server.communication.GameFinishedResponse(hitMsg, server.state)
-		return
+		return server.communication.GameFinishedResponse(ctx, server.event(eventType, hitMsg, outcome))
+	default:
+		return server.communication.HitResponse(ctx, server.event(eventType, hitMsg, outcome))
+	}
+}
+
+// waitForPlayer blocks until the player decides on their move and returns their request.
+// Requests to save or load the game are answered in the meantime without using up the
+// player's turn, as are aimed attacks at bee types the hive has none of left and items
+// the player has run out of.
+func (server *GameServer) waitForPlayer(ctx context.Context) (Request, error) {
+	for {
+		request, err := server.communication.WaitForPlayer(ctx)
+		if err != nil {
+			return Request{}, err
+		}
+
+		switch request.Type {
+		case HitRequest, DefendRequest:
+			return request, nil
+		case AimRequest:
+			if livingBees(server.state.Hive, request.Target) > 0 {
+				return request, nil
+			}
+
+			err = server.communication.RejectResponse(ctx, fmt.Errorf("there is no %s left to aim at", request.Target))
+		case UseRequest:
+			if server.state.Player.Inventory[request.Item] > 0 {
+				return request, nil
+			}
+
+			err = server.communication.RejectResponse(ctx, fmt.Errorf("you have no %s left", request.Item))
+		case SaveRequest:
+			save, saveErr := server.save()
+			err = server.communication.SaveResponse(ctx, save, saveErr)
+		case LoadRequest:
+			loadErr := server.restore(request.Save)
+			err = server.communication.LoadResponse(ctx, server.state.Snapshot(), loadErr)
+		}
+
+		if err != nil {
+			return Request{}, err
+		}
+	}
+}
+
+// save captures the game so far, including the state of its random source, so that it
+// can be resumed exactly where it was left. A game that is already over, e.g. because
+// the poison killed the player at the start of the round, can't be saved.
+func (server *GameServer) save() (SavedGame, error) {
+	if server.state.Player.Health <= 0 || len(server.state.Hive) == 0 || hasDeadQueen(server.state.Hive) {
+		return SavedGame{}, errors.New("the game is already over")
+	}
+
+	marshaler, ok := server.source.(encoding.BinaryMarshaler)
+	if !ok {
+		return SavedGame{}, errors.New("the game's random source can't be saved")
 	}
 
-	// If the bee that was just hit died (and wasn't the queen) we remove it from the hive.
-	if beeDied {
-		server.state.Hive = slices.Delete(server.state.Hive, beeIndex, beeIndex+1)
+	random, err := marshaler.MarshalBinary()
+	if err != nil {
+		return SavedGame{}, err
 	}
 
-	server.communication.HitResponse(hitMsg, server.state)
+	return SavedGame{
+		Version: saveVersion,
+		Config:  server.config,
+		State:   server.state.Snapshot(),
+		Random:  random,
+	}, nil
 }
 
-// hivesTurn handles the hive's action phase.
-// A random bee attempts to sting the player. Death or miss is resolved accordingly.
-func (server *GameServer) hivesTurn() {
-	// Select a random bee from the hive.
-	beeIndex := rand.IntN(len(server.state.Hive))
-	selectedBee := &server.state.Hive[beeIndex]
-	player := &server.state.Player
+// restore replaces the current game with a saved one. The game carries on from the
+// saved state with the saved rules and random source, and anything noted or kept to be
+// sent at the start of the current game's round is dropped. The save itself is left
+// untouched so it can be loaded again.
+func (server *GameServer) restore(save SavedGame) error {
+	if err := save.validate(); err != nil {
+		return err
+	}
 
-	// Check to see if the bee misses their shot.
-	if rand.UintN(101) <= selectedBee.MissChance {
-		msg := fmt.Sprintf("Buzz! That was close! The %s just missed you!", selectedBee.Type)
-		server.communication.StingResponse(msg, server.state)
-		return
+	source, err := save.source()
+	if err != nil {
+		return err
 	}
 
-	// Bee managed to successfully sting the player. Increment counter.
-	server.state.Stings += 1
+	server.finished = false
+	server.notice = ""
+	server.roundStart = nil
+	server.pending = nil
+	server.config = save.Config
+	server.state = save.State.Snapshot()
+	server.source = source
+	server.random = rand.New(source)
+
+	return nil
+}
+
+// hivesTurn handles the hive's action phase.
+// A random bee either uses its type's ability or attempts to sting the player. Death or
+// miss is resolved accordingly.
+func (server *GameServer) hivesTurn(ctx context.Context) error {
+	immune := server.state.Player.Immune
+	eventType, outcome := hiveMove(&server.state, server.config, server.random)
+
+	switch eventType {
+	case BeeMissed:
+		msg := fmt.Sprintf("Buzz! That was close! The %s just missed you!", outcome.BeeType)
+		if server.state.Hive[outcome.BeeIndex].Effects.Has(Stunned) {
+			msg = fmt.Sprintf("The %s is stunned and can't sting!", outcome.BeeType)
+		}
+
+		return server.communication.StingResponse(ctx, server.event(BeeMissed, msg, outcome))
+	case QueenFed:
+		msg := fmt.Sprintf("A %s feeds the Queen bee instead of stinging. She recovers %d HP and has %d HP left.", outcome.BeeType, outcome.HealthAfter-outcome.HealthBefore, outcome.HealthAfter)
 
-	// Determine the damage dealt to the player and generate a witty message.
-	playerDied := player.takeDamage(selectedBee.Type)
-	stingMsg := player.generateHitMessage(selectedBee.Type, playerDied)
+		return server.communication.StingResponse(ctx, server.event(QueenFed, msg, outcome))
+	case QueenCommanded:
+		msg := "The Queen bee issues a royal command! The hive's next sting is sure to land."
 
-	// If the player died the game is over.
-	if playerDied {
+		return server.communication.StingResponse(ctx, server.event(QueenCommanded, msg, outcome))
+	case PlayerKilled:
+		// The player died so the game is over.
 		server.finished = true
 
-		server.communication.GameFinishedResponse(stingMsg, server.state)
-		return
+		stingMsg := server.state.Player.generateHitMessage(outcome.BeeType, outcome.Damage, true, outcome.Critical)
+		if outcome.Swarm > 0 {
+			stingMsg = fmt.Sprintf("A swarm of %d %ss just killed you!", outcome.Swarm, outcome.BeeType)
+		}
+
+		return server.communication.GameFinishedResponse(ctx, server.event(PlayerKilled, stingMsg, outcome))
+	default:
+		stingMsg := server.state.Player.generateHitMessage(outcome.BeeType, outcome.Damage, false, outcome.Critical)
+
+		if eventType == BeesSwarmed {
+			stingMsg = fmt.Sprintf("Swarm! %d %ss dive at you, but every sting misses!", outcome.Swarm, outcome.BeeType)
+			if outcome.SwarmStings > 0 {
+				stingMsg = fmt.Sprintf("Swarm! %d %ss dive at you and %d of them sting you for %d damage. You have %d HP left.", outcome.Swarm, outcome.BeeType, outcome.SwarmStings, outcome.Damage, outcome.HealthAfter)
+			}
+		}
+
+		switch {
+		case immune && !server.state.Player.Immune:
+			stingMsg += " The antihistamine took the sting out of it."
+		case outcome.Blocked > 0:
+			stingMsg += fmt.Sprintf(" Your guard blocked %d damage.", outcome.Blocked)
+		}
+
+		if outcome.EffectInflicted {
+			stingMsg += fmt.Sprintf(" You are now %s!", outcome.Effect)
+		}
+
+		return server.communication.StingResponse(ctx, server.event(eventType, stingMsg, outcome))
+	}
+}
+
+// event records an event of the given type in the journal and builds the event
+// describing the current state of the game.
+func (server *GameServer) event(eventType EventType, msg string, outcome Outcome) Event {
+	server.record(eventType, outcome)
+
+	return server.describe(eventType, msg, outcome)
+}
+
+// record adds an entry for the event of the given type to the journal.
+func (server *GameServer) record(eventType EventType, outcome Outcome) {
+	server.state.Journal = append(server.state.Journal, createJournalEntry(server.state.Round, eventType, outcome))
+}
+
+// describe builds the event describing the current state of the game, without recording
+// it in the journal. Anything noted at the start of the round is told first and sent
+// along with it.
+func (server *GameServer) describe(eventType EventType, msg string, outcome Outcome) Event {
+	event := Event{
+		Type:       eventType,
+		Message:    server.notice + msg,
+		Outcome:    outcome,
+		RoundStart: server.roundStart,
+		State:      server.state.Snapshot(),
+	}
+
+	server.notice = ""
+	server.roundStart = nil
+
+	return event
+}
+
+// collapseHive kills every remaining bee once the hive has lost its queen and ends the game.
+func (server *GameServer) collapseHive(ctx context.Context) error {
+	collapse(&server.state)
+
+	server.finished = true
+
+	msg := "The hive collapses without its Queen!"
+	switch server.state.Collapsed {
+	case 0:
+	case 1:
+		msg += " The last remaining bee died with her."
+	default:
+		msg += fmt.Sprintf(" All %d remaining bees died with her.", server.state.Collapsed)
 	}
 
-	server.communication.StingResponse(stingMsg, server.state)
+	return server.communication.GameFinishedResponse(ctx, server.event(HiveCollapsed, msg, Outcome{}))
 }
@@ -1,16 +1,206 @@
 package game
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
 
 // TestStartupServer ensures that the StartupServer function returns a valid (non-nil) CommunicationProtocol.
 func TestStartupServer(t *testing.T) {
-	communication := StartupServer()
+	communication := StartupServer(context.Background(), DefaultGameConfig(), 1, nil)
 
 	if communication == nil {
 		t.Error("Expected StartupServer to return non-nil CommunicationProtocol")
 	}
 }
 
+// TestStartupServerSeed ensures that two servers started with the same seed play out
+// identically and that the seed is reported in the game state.
+func TestStartupServerSeed(t *testing.T) {
+	playGame := func(seed uint64) ([]string, GameState) {
+		communication := StartupServer(context.Background(), DefaultGameConfig(), seed, nil)
+		messages := []string{}
+
+		for {
+			for _, receive := range []func(context.Context) (Event, error){communication.Hit, communication.WaitForCPU} {
+				for _, event := range mustEvents(t, context.Background(), communication, receive) {
+					messages = append(messages, event.Message)
+
+					// Every event must be recorded in the journal it carries.
+					if len(event.State.Journal) != len(messages) || event.State.Journal[len(messages)-1].Type != event.Type {
+						t.Fatalf("Expected the journal to record every event. Events: %d. Journal entries: %d.", len(messages), len(event.State.Journal))
+					}
+
+					if event.Finished {
+						return messages, event.State
+					}
+				}
+			}
+		}
+	}
+
+	firstMessages, firstState := playGame(42)
+	secondMessages, secondState := playGame(42)
+
+	if !slices.Equal(firstMessages, secondMessages) {
+		t.Errorf("Expected games with the same seed to produce the same messages.")
+	}
+
+	if firstState.Round != secondState.Round || firstState.Hits != secondState.Hits || firstState.Stings != secondState.Stings {
+		t.Errorf("Expected games with the same seed to finish in the same state.")
+	}
+
+	if firstState.Seed != 42 {
+		t.Errorf("Expected game state to record seed 42. Received: %d", firstState.Seed)
+	}
+}
+
+// TestRunExitsOnQuit ensures the game loop returns instead of leaking its goroutine when
+// the client quits or the context is cancelled while the server is waiting.
+func TestRunExitsOnQuit(t *testing.T) {
+	newServer := func() (*GameServer, *CommunicationProtocol) {
+		communication := createCommunicationProtocol()
+
+		return &GameServer{
+			config:        DefaultGameConfig(),
+			random:        rand.New(NewSource(1)),
+			communication: communication,
+			state: GameState{
+				Player: createPlayer(DefaultGameConfig()),
+				Hive:   createHive(DefaultGameConfig()),
+			},
+		}, communication
+	}
+
+	waitForExit := func(name string, done <-chan error, expected error) {
+		select {
+		case err := <-done:
+			if !errors.Is(err, expected) {
+				t.Errorf("%s: expected run to return %v. Received: %v", name, expected, err)
+			}
+		case <-time.After(time.Second * 3):
+			t.Errorf("%s: timed out waiting for the game loop to exit.", name)
+		}
+	}
+
+	// Quit while the server waits for the player.
+	server, communication := newServer()
+	done := make(chan error)
+	go func() { done <- server.run(context.Background()) }()
+
+	communication.Quit()
+	waitForExit("quit", done, ErrQuit)
+
+	// Cancel while the server waits for the client to read an event.
+	server, communication = newServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { done <- server.run(ctx) }()
+
+	communication.requests <- Request{Type: HitRequest}
+	cancel()
+	waitForExit("cancel", done, context.Canceled)
+}
+
+// TestSaveAndRestore ensures a game resumed from a save plays out exactly like the
+// original game would have from the moment it was saved.
+func TestSaveAndRestore(t *testing.T) {
+	ctx := context.Background()
+	communication := StartupServer(ctx, DefaultGameConfig(), 99, nil)
+	defer communication.Quit()
+
+	// Play a few rounds before saving.
+	for range 3 {
+		mustEvent(t)(communication.Hit(ctx))
+		mustEvent(t)(communication.WaitForCPU(ctx))
+	}
+
+	save, err := communication.Save(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error saving the game: %s", err)
+	}
+
+	if save.Version != saveVersion || save.State.Round != 4 || len(save.Random) == 0 {
+		t.Fatalf("Saved game is incomplete: %+v", save)
+	}
+
+	playRounds := func(rounds int) []Event {
+		events := []Event{}
+
+		for range rounds {
+			for _, receive := range []func(context.Context) (Event, error){communication.Hit, communication.WaitForCPU} {
+				for _, event := range mustEvents(t, ctx, communication, receive) {
+					events = append(events, event)
+
+					if event.Finished {
+						return events
+					}
+				}
+			}
+		}
+
+		return events
+	}
+
+	original := playRounds(5)
+
+	state, err := communication.Load(ctx, save)
+	if err != nil {
+		t.Fatalf("Unexpected error loading the game: %s", err)
+	}
+
+	if state.Round != save.State.Round || len(state.Journal) != len(save.State.Journal) {
+		t.Errorf("Expected the game to resume from the saved state. Received: %+v", state)
+	}
+
+	resumed := playRounds(5)
+
+	if len(original) != len(resumed) {
+		t.Fatalf("Expected the resumed game to play out the same. Original events: %d. Resumed events: %d.", len(original), len(resumed))
+	}
+
+	for index := range original {
+		if original[index].Message != resumed[index].Message || original[index].Outcome != resumed[index].Outcome {
+			t.Errorf("Event %d differs after resuming. Original: \"%s\". Resumed: \"%s\".", index, original[index].Message, resumed[index].Message)
+		}
+	}
+
+	// Nothing noted at the start of the current game's round carries over to the loaded one.
+	config := DefaultGameConfig()
+	server := &GameServer{config: config, source: NewSource(1), state: NewGameState(config, 1)}
+
+	if save, err = server.save(); err != nil {
+		t.Fatalf("Unexpected error saving the game: %s", err)
+	}
+
+	server.notice = "The poison burns! You lose 5 HP. "
+	server.roundStart = []JournalEntry{createJournalEntry(1, PlayerPoisoned, Outcome{Damage: poisonDamage})}
+
+	if err = server.restore(save); err != nil || server.notice != "" || server.roundStart != nil {
+		t.Errorf("Expected loading a game to clear the notice. Received: %q, %v", server.notice, err)
+	}
+
+	// A source that can't be saved is reported instead of silently saving a broken game.
+	server = &GameServer{source: highSource, state: NewGameState(config, 1)}
+	if _, err := server.save(); err == nil {
+		t.Error("Expected an error saving a game with a random source that can't be saved.")
+	}
+
+	// A game the poison finished at the start of the round can't be saved, as it could
+	// never be loaded again.
+	server = &GameServer{config: config, source: NewSource(1), state: NewGameState(config, 1)}
+	server.state.Player.Health = 0
+
+	if _, err := server.save(); err == nil || err.Error() != "the game is already over" {
+		t.Errorf("Expected an error saving a game that's already over. Received: %v", err)
+	}
+}
+
 // TestRun verifies the full run loop for both player-win and player-loss scenarios.
 func TestRun(t *testing.T) {
 	mockProtocol := &MockProtocol{}
@@ -18,6 +208,8 @@ func TestRun(t *testing.T) {
 	// Scenario: Player wins by killing a bee.
 	server := &GameServer{
 		finished:      false,
+		config:        DefaultGameConfig(),
+		random:        rand.New(NewSource(1)),
 		communication: mockProtocol,
 		state: GameState{
 			Round:  0,
@@ -37,7 +229,7 @@ func TestRun(t *testing.T) {
 		},
 	}
 
-	server.run()
+	server.run(context.Background())
 
 	if !mockProtocol.finishedCalled {
 		t.Errorf("Game did not finish as expected.")
@@ -50,6 +242,8 @@ func TestRun(t *testing.T) {
 	// Scenario: Player loses due to hive attacks.
 	server = &GameServer{
 		finished:      false,
+		config:        DefaultGameConfig(),
+		random:        rand.New(NewSource(1)),
 		communication: mockProtocol,
 		state: GameState{
 			Player: Player{
@@ -66,7 +260,7 @@ func TestRun(t *testing.T) {
 		},
 	}
 
-	server.run()
+	server.run(context.Background())
 
 	if !mockProtocol.finishedCalled {
 		t.Errorf("Game did not finish as expected.")
@@ -90,6 +284,8 @@ func TestPlayersTurn(t *testing.T) {
 	}
 
 	server := &GameServer{
+		config:        DefaultGameConfig(),
+		random:        rand.New(NewSource(1)),
 		communication: mockProtocol,
 		state: GameState{
 			Player: Player{
@@ -106,7 +302,7 @@ func TestPlayersTurn(t *testing.T) {
 		},
 	}
 
-	server.playersTurn()
+	server.playersTurn(context.Background())
 
 	if server.state.Hits != 1 {
 		t.Errorf("Expected server.state.Hits to be 1.")
@@ -116,6 +312,10 @@ func TestPlayersTurn(t *testing.T) {
 		t.Errorf("Expected bee to be removed from hive after death.")
 	}
 
+	if !server.finished || !mockProtocol.finishedCalled {
+		t.Errorf("Expected the game to finish once the last bee was killed.")
+	}
+
 	if mockProtocol.currentMessage == "" {
 		t.Errorf("Expected playersTurn to return proper message.")
 	}
@@ -126,6 +326,8 @@ func TestPlayersTurn(t *testing.T) {
 
 	// Missed attack, bee remains.
 	server = &GameServer{
+		config:        DefaultGameConfig(),
+		random:        rand.New(NewSource(1)),
 		communication: &MockProtocol{},
 		state: GameState{
 			Player: Player{
@@ -142,7 +344,7 @@ func TestPlayersTurn(t *testing.T) {
 		},
 	}
 
-	server.playersTurn()
+	server.playersTurn(context.Background())
 
 	if server.state.Hits == 1 {
 		t.Errorf("Expected server.state.Hits to be 0.")
@@ -153,6 +355,337 @@ func TestPlayersTurn(t *testing.T) {
 	}
 }
 
+// TestPlayersTurnAim ensures aimed attacks land on the target type, and that aiming at a
+// type the hive has none of left is turned down without using up the turn.
+func TestPlayersTurnAim(t *testing.T) {
+	config := DefaultGameConfig()
+
+	worker := config.Bees[WorkerBee]
+	worker.AimMissChance = 0
+	config.Bees[WorkerBee] = worker
+
+	drone := config.Bees[DroneBee]
+	drone.AimMissChance = 100
+	config.Bees[DroneBee] = drone
+
+	mockProtocol := &MockProtocol{
+		requests: []Request{
+			{Type: AimRequest, Target: QueenBee},
+			{Type: AimRequest, Target: WorkerBee},
+			{Type: AimRequest, Target: DroneBee},
+		},
+	}
+
+	server := &GameServer{
+		config:        config,
+		random:        rand.New(NewSource(1)),
+		communication: mockProtocol,
+		state: GameState{
+			Player: Player{Health: 100, MissChance: 100},
+			Hive:   []Bee{{Type: DroneBee, Health: 60}, {Type: WorkerBee, Health: 75}},
+		},
+	}
+
+	if err := server.playersTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(mockProtocol.rejections) != 1 || mockProtocol.rejections[0].Error() != "there is no Queen bee left to aim at" {
+		t.Fatalf("Expected the aimed attack at the queen to be turned down. Received: %v", mockProtocol.rejections)
+	}
+
+	if event := mockProtocol.events[0]; event.Type != BeeHit || event.Outcome.BeeType != WorkerBee || !event.Outcome.Aimed {
+		t.Fatalf("Expected the worker bee to be hit. Received: %+v", event)
+	}
+
+	if err := server.playersTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if event := mockProtocol.events[1]; event.Type != PlayerMissed || event.Message != "Miss! The drone bee dodged your aim, better luck next time!" {
+		t.Errorf("Expected the aimed attack at the drone bee to miss. Received: %+v", event)
+	}
+}
+
+// TestPlayersTurnDefend ensures a defending player skips their attack and the hive's next
+// sting reports the damage their guard blocked.
+func TestPlayersTurnDefend(t *testing.T) {
+	mockProtocol := &MockProtocol{requests: []Request{{Type: DefendRequest}}}
+
+	server := &GameServer{
+		config:        DefaultGameConfig(),
+		random:        rand.New(highSource),
+		communication: mockProtocol,
+		state: GameState{
+			Player: createPlayer(DefaultGameConfig()),
+			Hive:   []Bee{{Type: QueenBee, Health: 100}},
+		},
+	}
+
+	if err := server.playersTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if event := mockProtocol.events[0]; event.Type != PlayerDefended || !event.State.Player.Defending || server.state.Hits != 0 || server.state.Hive[0].Health != 100 {
+		t.Fatalf("Expected the player to defend instead of attacking. Received: %+v", event)
+	}
+
+	if err := server.hivesTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if event := mockProtocol.events[1]; event.Outcome.Damage != 5 || !strings.HasSuffix(event.Message, "Your guard blocked 5 damage.") {
+		t.Errorf("Expected the queen's sting to be halved. Received: %+v", event)
+	}
+}
+
+// TestPlayersTurnUse ensures the player can use the items they carry instead of attacking,
+// and that items they've run out of are turned down without using up the turn.
+func TestPlayersTurnUse(t *testing.T) {
+	mockProtocol := &MockProtocol{
+		requests: []Request{
+			{Type: UseRequest, Item: SmokeCanister},
+			{Type: UseRequest, Item: HealingSalve},
+		},
+	}
+
+	server := &GameServer{
+		config:        DefaultGameConfig(),
+		random:        rand.New(NewSource(1)),
+		communication: mockProtocol,
+		state: GameState{
+			Player: Player{Health: 50, Inventory: map[Item]uint{HealingSalve: 1}},
+			Hive:   []Bee{{Type: QueenBee, Health: 100}},
+		},
+	}
+
+	if err := server.playersTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(mockProtocol.rejections) != 1 || mockProtocol.rejections[0].Error() != "you have no smoke canister left" {
+		t.Fatalf("Expected the smoke canister to be turned down. Received: %v", mockProtocol.rejections)
+	}
+
+	event := mockProtocol.events[0]
+	if event.Type != ItemUsed || event.Message != "You apply a healing salve and recover 25 HP. You have 75 HP left." || server.state.Hits != 0 {
+		t.Fatalf("Expected the player to use the healing salve instead of attacking. Received: %+v", event)
+	}
+
+	if len(event.State.Player.Inventory) != 0 || event.State.Journal[0].String() != "Round 0: You used healing salve (50 → 75 HP)" {
+		t.Errorf("Expected the salve to be used up and journaled. Received: %+v", event.State)
+	}
+}
+
+// TestPlayersTurnStatusEffects ensures a stunned player loses their turn and is told what
+// the poison did at the start of the round, which can decide the game.
+func TestPlayersTurnStatusEffects(t *testing.T) {
+	mockProtocol := &MockProtocol{requests: []Request{{Type: HitRequest}, {Type: HitRequest}, {Type: HitRequest}}}
+
+	server := &GameServer{
+		config:        withoutSpawning(DefaultGameConfig()),
+		random:        rand.New(highSource),
+		communication: mockProtocol,
+		state: GameState{
+			Player: Player{Health: 10, Effects: Effects{Poisoned: 3, Stunned: 2}},
+			Hive:   []Bee{{Type: QueenBee, Health: 100}, {Type: DroneBee, Health: 5, Effects: Effects{Poisoned: 2}}},
+		},
+	}
+
+	server.startRound()
+
+	if err := server.playersTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	event := mockProtocol.events[0]
+	if event.Type != PlayerStunned || event.Message != "The poison burns! You lose 5 HP. The poison killed the drone bee. You're stunned and can't move this round!" {
+		t.Fatalf("Expected the poisoned player to be stunned. Received: %+v", event)
+	}
+
+	if server.state.Hits != 0 || len(event.State.Hive) != 1 || len(event.State.Journal) != 3 || event.State.Journal[2].Type != PlayerStunned {
+		t.Fatalf("Expected the stunned turn and the poison to be journaled. Received: %+v", event.State)
+	}
+
+	server.startRound()
+
+	if err := server.playersTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	event = mockProtocol.events[1]
+	if !event.Finished || event.Type != PlayerPoisoned || event.Message != "The poison burns! You lose 5 HP. The poison finished you off!" || event.Outcome.HealthAfter != 0 {
+		t.Fatalf("Expected the poison to kill the player. Received: %+v", event)
+	}
+
+	if len(event.State.Journal) != 4 {
+		t.Errorf("Expected the fatal poison to be journaled once. Received: %v", event.State.Journal)
+	}
+}
+
+// TestPlayersTurnAfflictsBee ensures the player is told about the status effect their hit
+// left the bee with, which the bee then suffers from.
+func TestPlayersTurnAfflictsBee(t *testing.T) {
+	config, _ := DefaultGameConfig().WithSetting("player.effect_chance", 100)
+	mockProtocol := &MockProtocol{requests: []Request{{Type: HitRequest}}}
+
+	server := &GameServer{
+		config:        config,
+		random:        rand.New(highSource),
+		communication: mockProtocol,
+		state: GameState{
+			Player: Player{Health: 100},
+			Hive:   []Bee{{Type: QueenBee, Health: 100}},
+		},
+	}
+
+	if err := server.playersTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	event := mockProtocol.events[0]
+	if event.Type != BeeHit || event.Message != "Direct Hit! Queen took 10 hit points. 90 HP left. The Queen bee is now poisoned!" {
+		t.Fatalf("Expected the hit to poison the queen. Received: %+v", event)
+	}
+
+	server.state.Round++
+	server.startRound()
+
+	if health := server.state.Hive[0].Health; health != 90-poisonDamage {
+		t.Errorf("Expected the poison to hurt the queen. Received: %d HP", health)
+	}
+}
+
+// TestHiveCollapse ensures that killing the last queen kills every remaining bee, while
+// killing one of several queens only removes her from the hive.
+func TestHiveCollapse(t *testing.T) {
+	mockProtocol := &MockProtocol{}
+
+	server := &GameServer{
+		config:        DefaultGameConfig(),
+		random:        rand.New(highSource), // Always hit the last bee in the hive.
+		communication: mockProtocol,
+		state: GameState{
+			Player: Player{
+				Health:     100,
+				MissChance: 0,
+			},
+			Hive: []Bee{
+				{Type: WorkerBee, Health: 75},
+				{Type: DroneBee, Health: 60},
+				{Type: QueenBee, Health: 1},
+			},
+		},
+	}
+
+	server.playersTurn(context.Background())
+
+	if !server.finished || !mockProtocol.finishedCalled {
+		t.Fatal("Expected the game to finish once the queen was killed.")
+	}
+
+	for _, bee := range server.state.Hive {
+		if bee.Health > 0 {
+			t.Errorf("Expected every bee to die in the collapse. A %s has %d HP left.", bee.Type, bee.Health)
+		}
+	}
+
+	if server.state.Collapsed != 2 {
+		t.Errorf("Expected 2 bees to die in the collapse. Received: %d", server.state.Collapsed)
+	}
+
+	if mockProtocol.currentState.Collapsed != 2 || mockProtocol.currentMessage != "The hive collapses without its Queen! All 2 remaining bees died with her." {
+		t.Errorf("Expected the collapse to be reported when the game finished. Received: \"%s\"", mockProtocol.currentMessage)
+	}
+
+	// Killing one of two queens leaves the hive standing.
+	mockProtocol = &MockProtocol{}
+
+	server = &GameServer{
+		config:        DefaultGameConfig(),
+		random:        rand.New(highSource),
+		communication: mockProtocol,
+		state: GameState{
+			Player: Player{
+				Health:     100,
+				MissChance: 0,
+			},
+			Hive: []Bee{
+				{Type: QueenBee, Health: 100},
+				{Type: QueenBee, Health: 1},
+			},
+		},
+	}
+
+	server.playersTurn(context.Background())
+
+	if server.finished || mockProtocol.finishedCalled {
+		t.Error("Expected the game to continue while a queen is alive.")
+	}
+
+	if len(server.state.Hive) != 1 || server.state.Collapsed != 0 {
+		t.Errorf("Expected the dead queen to be removed without a collapse. Hive: %+v", server.state.Hive)
+	}
+}
+
+// TestEventOutcomes ensures every kind of attack is reported with its own event type and
+// a structured outcome describing the bee, the roll and the target's health.
+func TestEventOutcomes(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		playerTurn      bool
+		player          Player
+		bee             Bee
+		expectedTypes   []EventType
+		expectedOutcome Outcome
+	}{
+		{"player missed", true, Player{Health: 100, MissChance: 100}, Bee{Type: DroneBee, Health: 60}, []EventType{PlayerMissed}, Outcome{BeeIndex: 1, BeeType: DroneBee, Roll: 99, Damage: 0, HealthBefore: 60, HealthAfter: 60}},
+		{"bee hit", true, Player{Health: 100}, Bee{Type: DroneBee, Health: 60}, []EventType{BeeHit}, Outcome{BeeIndex: 1, BeeType: DroneBee, Roll: 99, Damage: 30, HealthBefore: 60, HealthAfter: 30}},
+		{"bee killed", true, Player{Health: 100}, Bee{Type: DroneBee, Health: 30}, []EventType{BeeKilled}, Outcome{BeeIndex: 1, BeeType: DroneBee, Roll: 99, Damage: 30, HealthBefore: 30, HealthAfter: 0}},
+		{"queen killed", true, Player{Health: 100}, Bee{Type: QueenBee, Health: 10}, []EventType{QueenKilled, HiveCollapsed}, Outcome{BeeIndex: 1, BeeType: QueenBee, Roll: 99, Damage: 10, HealthBefore: 10, HealthAfter: 0}},
+		{"bee missed", false, Player{Health: 100}, Bee{Type: WorkerBee, Health: 75, MissChance: 100}, []EventType{BeeMissed}, Outcome{BeeIndex: 1, BeeType: WorkerBee, Roll: 99, Damage: 0, HealthBefore: 100, HealthAfter: 100}},
+		{"player stung", false, Player{Health: 100}, Bee{Type: WorkerBee, Health: 75}, []EventType{PlayerStung}, Outcome{BeeIndex: 1, BeeType: WorkerBee, Roll: 99, Damage: 5, HealthBefore: 100, HealthAfter: 95}},
+		{"player killed", false, Player{Health: 5}, Bee{Type: WorkerBee, Health: 75}, []EventType{PlayerKilled}, Outcome{BeeIndex: 1, BeeType: WorkerBee, Roll: 99, Damage: 5, HealthBefore: 5, HealthAfter: 0}},
+	}
+
+	for _, scenario := range scenarios {
+		mockProtocol := &MockProtocol{}
+		server := &GameServer{
+			config:        DefaultGameConfig(),
+			random:        rand.New(highSource), // Always pick the last bee and roll 100.
+			communication: mockProtocol,
+			state: GameState{
+				Player: scenario.player,
+				Hive:   []Bee{{Type: WorkerBee, Health: 75, MissChance: 100}, scenario.bee},
+			},
+		}
+
+		if scenario.playerTurn {
+			server.playersTurn(context.Background())
+		} else {
+			server.hivesTurn(context.Background())
+		}
+
+		receivedTypes := []EventType{}
+		for _, event := range mockProtocol.events {
+			receivedTypes = append(receivedTypes, event.Type)
+		}
+
+		if !slices.Equal(receivedTypes, scenario.expectedTypes) {
+			t.Errorf("%s: unexpected event types. Expected: %v. Received: %v.", scenario.name, scenario.expectedTypes, receivedTypes)
+			continue
+		}
+
+		if mockProtocol.events[0].Outcome != scenario.expectedOutcome {
+			t.Errorf("%s: unexpected outcome. Expected: %+v. Received: %+v.", scenario.name, scenario.expectedOutcome, mockProtocol.events[0].Outcome)
+		}
+
+		if mockProtocol.events[0].Type.String() == "" {
+			t.Errorf("%s: event type %d has no name.", scenario.name, mockProtocol.events[0].Type)
+		}
+	}
+}
+
 // TestHivesTurn simulates the hive’s retaliation phase against the player:
 // - One test ensures the bee misses.
 // - One ensures the player is killed.
@@ -160,6 +693,8 @@ func TestPlayersTurn(t *testing.T) {
 func TestHivesTurn(t *testing.T) {
 	// Hive misses the player.
 	server := &GameServer{
+		config:        DefaultGameConfig(),
+		random:        rand.New(NewSource(1)),
 		communication: &MockProtocol{},
 		state: GameState{
 			Player: Player{
@@ -176,7 +711,7 @@ func TestHivesTurn(t *testing.T) {
 		},
 	}
 
-	server.hivesTurn()
+	server.hivesTurn(context.Background())
 
 	if server.state.Stings == 1 {
 		t.Errorf("Expected server.state.Stings to be 0.")
@@ -188,6 +723,8 @@ func TestHivesTurn(t *testing.T) {
 
 	// Hive kills the player.
 	server = &GameServer{
+		config:        DefaultGameConfig(),
+		random:        rand.New(highSource),
 		communication: &MockProtocol{},
 		state: GameState{
 			Player: Player{
@@ -204,7 +741,7 @@ func TestHivesTurn(t *testing.T) {
 		},
 	}
 
-	server.hivesTurn()
+	server.hivesTurn(context.Background())
 
 	if server.state.Stings != 1 {
 		t.Errorf("Expected server.state.Stings to be 1.")
@@ -223,6 +760,8 @@ func TestHivesTurn(t *testing.T) {
 	}
 
 	server = &GameServer{
+		config:        DefaultGameConfig(),
+		random:        rand.New(highSource),
 		communication: mockProtocol,
 		state: GameState{
 			Player: Player{
@@ -239,7 +778,7 @@ func TestHivesTurn(t *testing.T) {
 		},
 	}
 
-	server.hivesTurn()
+	server.hivesTurn(context.Background())
 
 	if mockProtocol.currentMessage == "" {
 		t.Errorf("Expected hivesTurn to return proper message.")
@@ -250,6 +789,235 @@ func TestHivesTurn(t *testing.T) {
 	}
 }
 
+// TestHivesTurnStatusEffects ensures stunned bees can't sting and that stings tell the
+// player about the status effects they inflict.
+func TestHivesTurnStatusEffects(t *testing.T) {
+	config, _ := DefaultGameConfig().WithSetting("worker.effect_chance", 100)
+	mockProtocol := &MockProtocol{}
+
+	server := &GameServer{
+		config:        config,
+		random:        rand.New(highSource),
+		communication: mockProtocol,
+		state: GameState{
+			Player: Player{Health: 100},
+			Hive:   []Bee{{Type: WorkerBee, Health: 75, Effects: Effects{Stunned: 1}}},
+		},
+	}
+
+	if err := server.hivesTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if event := mockProtocol.events[0]; event.Type != BeeMissed || event.Message != "The worker bee is stunned and can't sting!" {
+		t.Fatalf("Expected the stunned worker not to sting. Received: %+v", event)
+	}
+
+	server.state.Hive[0].Effects = Effects{}
+
+	if err := server.hivesTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if event := mockProtocol.events[1]; !strings.HasSuffix(event.Message, "You are now poisoned!") || !event.State.Player.Effects.Has(Poisoned) {
+		t.Errorf("Expected the worker's sting to poison the player. Received: %+v", event)
+	}
+}
+
+// TestHivesTurnAbilities ensures the player is told about every ability the bees use.
+func TestHivesTurnAbilities(t *testing.T) {
+	config := DefaultGameConfig()
+	for _, setting := range []string{"queen.ability_chance", "worker.ability_chance", "drone.ability_chance"} {
+		config, _ = config.WithSetting(setting, 100)
+	}
+
+	mockProtocol := &MockProtocol{}
+
+	server := &GameServer{
+		config:        config,
+		random:        rand.New(highSource),
+		communication: mockProtocol,
+		state: GameState{
+			Player: Player{Health: 100},
+			Hive:   []Bee{{Type: QueenBee, Health: 50}, {Type: WorkerBee, Health: 75}},
+		},
+	}
+
+	expectedMessages := []string{
+		"A worker bee feeds the Queen bee instead of stinging. She recovers 10 HP and has 60 HP left.",
+		"The Queen bee issues a royal command! The hive's next sting is sure to land.",
+		"Swarm! 2 drone bees dive at you and 2 of them sting you for 4 damage. You have 96 HP left.",
+		"A swarm of 2 drone bees just killed you!",
+	}
+
+	hives := [][]Bee{
+		nil,
+		{{Type: WorkerBee, Health: 75}, {Type: QueenBee, Health: 100}},
+		{{Type: DroneBee, Health: 60}, {Type: DroneBee, Health: 60}},
+		nil,
+	}
+
+	for index, expected := range expectedMessages {
+		if hives[index] != nil {
+			server.state.Hive = hives[index]
+		}
+
+		if index == len(expectedMessages)-1 {
+			server.state.Player.Health = 4
+		}
+
+		if err := server.hivesTurn(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if event := mockProtocol.events[index]; event.Message != expected {
+			t.Errorf("Unexpected message. Expected: \"%s\". Received: \"%s\"", expected, event.Message)
+		}
+	}
+
+	if !server.finished || len(server.state.Journal) != len(expectedMessages) {
+		t.Errorf("Expected the swarm to finish the game with every ability journaled. Received: %v", server.state.Journal)
+	}
+}
+
+// TestStartRoundHatchesDrones ensures the drones that hatch from the queen's eggs are
+// journaled and sent as an event of their own before the player's move.
+func TestStartRoundHatchesDrones(t *testing.T) {
+	config := DefaultGameConfig()
+	config.SpawnInterval = 1
+	config.SpawnCount = 2
+	config.SpawnCap = 3
+
+	mockProtocol := &MockProtocol{requests: []Request{{Type: DefendRequest}, {Type: DefendRequest}}}
+
+	server := &GameServer{
+		config:        config,
+		random:        rand.New(highSource),
+		communication: mockProtocol,
+		state: GameState{
+			Round:  1,
+			Player: Player{Health: 100},
+			Hive:   []Bee{{Type: QueenBee, Health: 100}},
+		},
+	}
+
+	for _, expected := range []struct {
+		msg     string
+		hatched uint
+	}{
+		{"The Queen's eggs hatch into 2 new drones!", 2},
+		{"The Queen's eggs hatch into a new drone!", 1},
+	} {
+		server.startRound()
+		sent := len(mockProtocol.events)
+
+		if err := server.playersTurn(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		events := mockProtocol.events[sent:]
+		if len(events) != 2 || events[0].Type != DronesHatched || events[1].Type != PlayerDefended {
+			t.Fatalf("Expected the hatching to be sent before the player's move. Received: %+v", events)
+		}
+
+		if events[0].Message != expected.msg || events[0].Outcome.Hatched != expected.hatched || events[0].Outcome.BeeType != DroneBee {
+			t.Errorf("Unexpected hatching. Expected: %q. Received: %+v", expected.msg, events[0])
+		}
+
+		if events[1].Message != "You raise your guard and brace for the next sting." {
+			t.Errorf("Expected the player's move to be told on its own. Received: %q", events[1].Message)
+		}
+
+		if err := server.hivesTurn(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if len(mockProtocol.events) != sent+3 {
+			t.Errorf("Expected the hatching to be sent only once. Received: %+v", mockProtocol.events[sent:])
+		}
+	}
+
+	journal := server.state.Journal
+	if len(server.state.Hive) != 4 || len(journal) != 6 || journal[0].Type != DronesHatched || journal[0].Outcome.Hatched != 2 || journal[3].Outcome.Hatched != 1 {
+		t.Errorf("Expected both clutches to hatch and be journaled. Received: %v", journal)
+	}
+}
+
+// TestDronesHatchedEvent ensures a client playing through the protocol receives the
+// drones that hatched as an event of its own, followed by the event for its move.
+func TestDronesHatchedEvent(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultGameConfig()
+	config.SpawnInterval = 1
+	config.SpawnCount = 1
+	config.SpawnCap = 30
+
+	communication := StartupServer(ctx, config, 8, nil)
+	defer communication.Quit()
+
+	event := mustEvent(t)(communication.Defend(ctx))
+	if event.Type != DronesHatched || event.Finished || event.Outcome.Hatched != 1 || len(event.State.Hive) != 32 {
+		t.Fatalf("Expected the drone that hatched to be sent first. Received: %+v", event)
+	}
+
+	if event = mustEvent(t)(communication.WaitForCPU(ctx)); event.Type != PlayerDefended {
+		t.Fatalf("Expected the player's move to follow the hatching. Received: %+v", event)
+	}
+
+	if event = mustEvent(t)(communication.WaitForCPU(ctx)); event.Type == DronesHatched || event.Type == PlayerDefended {
+		t.Errorf("Expected the hive's move to follow the player's. Received: %+v", event)
+	}
+}
+
+// TestCriticalHitMessages ensures critical hits are called out on both sides.
+func TestCriticalHitMessages(t *testing.T) {
+	config := DefaultGameConfig()
+	config.PlayerCritChance = 100
+	config, _ = config.WithSetting("drone.crit_chance", 100)
+
+	mockProtocol := &MockProtocol{requests: []Request{{Type: HitRequest}}}
+
+	server := &GameServer{
+		config:        config,
+		random:        rand.New(highSource),
+		communication: mockProtocol,
+		state: GameState{
+			Player: Player{Health: 100},
+			Hive:   []Bee{{Type: DroneBee, Health: 60}},
+		},
+	}
+
+	if err := server.playersTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if event := mockProtocol.events[0]; event.Message != "Critical hit! 45 damage to a drone bee. 15 HP left." || !event.Outcome.Critical {
+		t.Fatalf("Expected a critical hit on the drone. Received: %+v", event)
+	}
+
+	if err := server.hivesTurn(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if event := mockProtocol.events[1]; event.Message != "Critical sting! A drone bee stung you for 1 damage. You have 99 HP left." || !event.Outcome.Critical {
+		t.Errorf("Expected a critical sting from the drone. Received: %+v", event)
+	}
+}
+
+// --- Mock Random Source ---
+
+// fixedSource is a rand.Source that always returns the same value. It lets tests pin
+// every roll to one end of its range instead of relying on a lucky seed.
+type fixedSource uint64
+
+// Uint64 returns the fixed value.
+func (source fixedSource) Uint64() uint64 {
+	return uint64(source)
+}
+
+// highSource makes every roll land on the highest value in its range.
+const highSource = fixedSource(math.MaxUint64)
+
 // --- Mock Protocol ---
 
 // MockProtocol implements the Protocol interface with test-friendly behavior.
@@ -257,34 +1025,226 @@ type MockProtocol struct {
 	finishedCalled bool
 	currentMessage string
 	currentState   GameState
+	events         []Event
+	requests       []Request // Requests the player sends before attacking at random.
+	rejections     []error
 }
 
 // Hit is unused in these tests but required to implement the interface
-func (m *MockProtocol) Hit() Event {
-	return Event{}
+func (m *MockProtocol) Hit(ctx context.Context) (Event, error) {
+	return Event{}, nil
+}
+
+// Defend is unused in server tests.
+func (m *MockProtocol) Defend(ctx context.Context) (Event, error) {
+	return Event{}, nil
+}
+
+// Use is unused in server tests.
+func (m *MockProtocol) Use(ctx context.Context, item Item) (Event, error) {
+	return Event{}, nil
+}
+
+// Aim is unused in server tests.
+func (m *MockProtocol) Aim(ctx context.Context, target BeeType) (Event, error) {
+	return Event{}, nil
 }
 
 // WaitForCPU is unused in server tests.
-func (m *MockProtocol) WaitForCPU() Event {
-	return Event{}
+func (m *MockProtocol) WaitForCPU(ctx context.Context) (Event, error) {
+	return Event{}, nil
 }
 
-// WaitForPlayer is unused in server tests.
-func (m *MockProtocol) WaitForPlayer() {}
+// Save is unused in server tests.
+func (m *MockProtocol) Save(ctx context.Context) (SavedGame, error) {
+	return SavedGame{}, nil
+}
+
+// Load is unused in server tests.
+func (m *MockProtocol) Load(ctx context.Context, save SavedGame) (GameState, error) {
+	return GameState{}, nil
+}
+
+// WaitForPlayer hands out the queued requests, then lets the player attack straight away.
+func (m *MockProtocol) WaitForPlayer(ctx context.Context) (Request, error) {
+	if len(m.requests) > 0 {
+		request := m.requests[0]
+		m.requests = m.requests[1:]
+
+		return request, nil
+	}
+
+	return Request{Type: HitRequest}, nil
+}
 
 // HitResponse simulates sending a result from a player hit.
-func (m *MockProtocol) HitResponse(msg string, state GameState) {
-	m.currentMessage = msg
-	m.currentState = state
+func (m *MockProtocol) HitResponse(ctx context.Context, event Event) error {
+	m.record(event)
+
+	return nil
 }
 
 // StingResponse simulates sending a result from a bee attack.
-func (m *MockProtocol) StingResponse(msg string, state GameState) {
-	m.currentMessage = msg
-	m.currentState = state
+func (m *MockProtocol) StingResponse(ctx context.Context, event Event) error {
+	m.record(event)
+
+	return nil
 }
 
 // GameFinishedResponse records that the game has concluded.
-func (m *MockProtocol) GameFinishedResponse(msg string, state GameState) {
+func (m *MockProtocol) GameFinishedResponse(ctx context.Context, event Event) error {
 	m.finishedCalled = true
+	event.Finished = true
+	m.record(event)
+
+	return nil
+}
+
+// record keeps track of every event the server sent.
+func (m *MockProtocol) record(event Event) {
+	m.currentMessage = event.Message
+	m.currentState = event.State
+	m.events = append(m.events, event)
+}
+
+// SaveResponse is unused in server tests.
+func (m *MockProtocol) SaveResponse(ctx context.Context, save SavedGame, err error) error {
+	return nil
+}
+
+// LoadResponse is unused in server tests.
+func (m *MockProtocol) LoadResponse(ctx context.Context, state GameState, err error) error {
+	return nil
+}
+
+// RejectResponse records the reason the player's move was turned down.
+func (m *MockProtocol) RejectResponse(ctx context.Context, err error) error {
+	m.rejections = append(m.rejections, err)
+
+	return nil
+}
+
+// Quit is unused in server tests.
+func (m *MockProtocol) Quit() {}
+
+// mustEvent unwraps an event returned by the protocol, failing the test on an error.
+func mustEvent(t *testing.T) func(Event, error) Event {
+	return func(event Event, err error) Event {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("Unexpected protocol error: %s", err)
+		}
+
+		return event
+	}
+}
+
+// mustEvents receives the event receive waits for, after any DronesHatched events sent
+// before it, failing the test on an error.
+func mustEvents(tb testing.TB, ctx context.Context, communication Protocol, receive func(context.Context) (Event, error)) []Event {
+	tb.Helper()
+
+	events := []Event{}
+
+	for {
+		event, err := receive(ctx)
+		if err != nil {
+			tb.Fatalf("Unexpected protocol error: %s", err)
+		}
+
+		events = append(events, event)
+
+		if event.Type != DronesHatched {
+			return events
+		}
+
+		receive = communication.WaitForCPU
+	}
+}
+
+// TestEventSnapshots ensures every event carries a snapshot of the game that never
+// changes, even while the game carries on. Run it with -race to prove that holding onto
+// old events doesn't race with the server.
+func TestEventSnapshots(t *testing.T) {
+	ctx := context.Background()
+	communication := StartupServer(ctx, DefaultGameConfig(), 5, nil)
+	defer communication.Quit()
+
+	held := make(chan Event, 1024)
+	done := make(chan int)
+
+	// Keep reading every event received so far while the game is played.
+	go func() {
+		events := []Event{}
+		health := 0
+
+		for event := range held {
+			events = append(events, event)
+
+			for _, old := range events {
+				for _, bee := range old.State.Hive {
+					health += bee.Health
+				}
+
+				health += len(old.State.Journal)
+			}
+		}
+
+		done <- health
+	}()
+
+	events := []Event{}
+
+	for finished := false; !finished; {
+		for _, receive := range []func(context.Context) (Event, error){communication.Hit, communication.WaitForCPU} {
+			for _, event := range mustEvents(t, ctx, communication, receive) {
+				events = append(events, event)
+				held <- event
+				finished = event.Finished
+			}
+
+			if finished {
+				break
+			}
+		}
+	}
+
+	close(held)
+	<-done
+
+	for index, event := range events {
+		if len(event.State.Journal) != index+1 {
+			t.Fatalf("Event %d: expected %d journal entries. Received: %d", index, index+1, len(event.State.Journal))
+		}
+
+		switch event.Type {
+		case BeeHit:
+			if bee := event.State.Hive[event.Outcome.BeeIndex]; bee.Health != event.Outcome.HealthAfter {
+				t.Fatalf("Event %d: expected the bee that was hit to have %d HP. Received: %d", index, event.Outcome.HealthAfter, bee.Health)
+			}
+		case PlayerStung, PlayerKilled:
+			if event.State.Player.Health != event.Outcome.HealthAfter {
+				t.Fatalf("Event %d: expected the player to have %d HP. Received: %d", index, event.Outcome.HealthAfter, event.State.Player.Health)
+			}
+		}
+	}
+}
+
+// TestSnapshot ensures a snapshot shares no memory with the state it was taken from.
+func TestSnapshot(t *testing.T) {
+	state := GameState{
+		Player:  Player{Inventory: map[Item]uint{HealingSalve: 1}},
+		Hive:    []Bee{{Type: DroneBee, Health: 60}},
+		Journal: []JournalEntry{{Round: 1, Type: BeeHit}},
+	}
+
+	snapshot := state.Snapshot()
+	state.Player.Inventory[HealingSalve] = 0
+	state.Hive[0].Health = 30
+	state.Journal[0].Type = BeeKilled
+
+	if snapshot.Player.Inventory[HealingSalve] != 1 || snapshot.Hive[0].Health != 60 || snapshot.Journal[0].Type != BeeHit {
+		t.Errorf("Expected the snapshot not to change with the state. Received: %+v", snapshot)
+	}
 }
@@ -0,0 +1,182 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+)
+
+// GameResult summarizes how a single simulated game ended.
+type GameResult struct {
+	Seed         uint64
+	Won          bool // Whether the player survived the hive.
+	Rounds       uint
+	Hits         uint
+	Stings       uint
+	PlayerHealth int
+	Survivors    map[BeeType]uint // Bees of every type still alive when the game ended.
+	QueenDied    bool
+}
+
+// createGameResult summarizes the final state of a game.
+func createGameResult(state GameState) GameResult {
+	result := GameResult{
+		Seed:         state.Seed,
+		Won:          state.Player.Health > 0,
+		Rounds:       state.Round,
+		Hits:         state.Hits,
+		Stings:       state.Stings,
+		PlayerHealth: state.Player.Health,
+		Survivors:    map[BeeType]uint{},
+	}
+
+	for _, bee := range state.Hive {
+		switch {
+		case bee.Health > 0:
+			result.Survivors[bee.Type]++
+		case bee.Type == QueenBee:
+			// Dead bees are removed from the hive, except for the queen that took the hive
+			// down with her.
+			result.QueenDied = true
+		}
+	}
+
+	return result
+}
+
+// Simulate plays the given number of complete games by the rules in config without a
+// client, spread across workers goroutines. The games are played with the seeds seed,
+// seed+1, and so on, so any of them can be played again by hand. The results are
+// returned in seed order.
+//
+// Simulate stops early and returns the context's error if ctx is cancelled.
+func Simulate(ctx context.Context, config GameConfig, games, workers int, seed uint64) ([]GameResult, error) {
+	if games < 0 {
+		return nil, errors.New("the number of games must not be negative")
+	}
+
+	if workers <= 0 {
+		return nil, errors.New("the number of workers must be > 0")
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	results := make([]GameResult, games)
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(workers, max(games, 1)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			game := createHeadlessGame(config)
+
+			for index := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				results[index] = createGameResult(*game.play(seed + uint64(index)))
+			}
+		}()
+	}
+
+	for index := range games {
+		if ctx.Err() != nil {
+			break
+		}
+
+		jobs <- index
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Distribution describes how a statistic was spread across a batch of games.
+type Distribution struct {
+	Mean float64
+	P50  uint
+	P90  uint
+	P99  uint
+	Max  uint
+}
+
+// createDistribution describes the spread of values, using the nearest-rank percentile.
+func createDistribution(values []uint) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+
+	sorted := slices.Sorted(slices.Values(values))
+
+	var total float64
+	for _, value := range sorted {
+		total += float64(value)
+	}
+
+	percentile := func(p float64) uint {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		return sorted[max(rank-1, 0)]
+	}
+
+	return Distribution{
+		Mean: total / float64(len(sorted)),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P99:  percentile(99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// SimulationReport summarizes a batch of simulated games.
+type SimulationReport struct {
+	Games   int
+	Wins    int
+	WinRate float64 // The fraction of games the player won, between 0 and 1.
+	Rounds  Distribution
+	Hits    Distribution
+	Stings  Distribution
+}
+
+// Summarize reports how the player fared across a batch of simulated games.
+func Summarize(results []GameResult) SimulationReport {
+	report := SimulationReport{Games: len(results)}
+
+	rounds := make([]uint, len(results))
+	hits := make([]uint, len(results))
+	stings := make([]uint, len(results))
+
+	for index, result := range results {
+		if result.Won {
+			report.Wins++
+		}
+
+		rounds[index] = result.Rounds
+		hits[index] = result.Hits
+		stings[index] = result.Stings
+	}
+
+	if report.Games > 0 {
+		report.WinRate = float64(report.Wins) / float64(report.Games)
+	}
+
+	report.Rounds = createDistribution(rounds)
+	report.Hits = createDistribution(hits)
+	report.Stings = createDistribution(stings)
+
+	return report
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestSimulate ensures simulated games play out exactly like the same seeds played
+// through the protocol, no matter how many workers share the games.
+func TestSimulate(t *testing.T) {
+	config := DefaultGameConfig()
+
+	results, err := Simulate(context.Background(), config, 20, 4, 100)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(results) != 20 {
+		t.Fatalf("Expected 20 results. Received: %d", len(results))
+	}
+
+	serial, err := Simulate(context.Background(), config, 20, 1, 100)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(results, serial) {
+		t.Error("Expected the results not to depend on the number of workers.")
+	}
+
+	for _, index := range []int{0, 7, 19} {
+		events, _ := recordGame(t, 100+uint64(index))
+		expected := createGameResult(events[len(events)-1].State)
+
+		if !reflect.DeepEqual(results[index], expected) {
+			t.Errorf("Simulated game %d differs from the played game. Expected: %+v. Received: %+v.", index, expected, results[index])
+		}
+	}
+}
+
+// TestSimulateErrors ensures invalid simulations and cancelled ones are reported.
+func TestSimulateErrors(t *testing.T) {
+	config := DefaultGameConfig()
+
+	if _, err := Simulate(context.Background(), config, 10, 0, 1); err == nil {
+		t.Error("Expected an error without any workers.")
+	}
+
+	if _, err := Simulate(context.Background(), config, -1, 1, 1); err == nil {
+		t.Error("Expected an error for a negative number of games.")
+	}
+
+	if _, err := Simulate(context.Background(), GameConfig{}, 10, 1, 1); err == nil {
+		t.Error("Expected an error for invalid rules.")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := Simulate(ctx, config, 10, 2, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled. Received: %v", err)
+	}
+}
+
+// TestCreateGameResult ensures the result of a game is read correctly from its final state.
+func TestCreateGameResult(t *testing.T) {
+	state := GameState{
+		Seed:   9,
+		Player: Player{Health: 40},
+		Hive: []Bee{
+			{Type: QueenBee, Health: 0},
+			{Type: WorkerBee, Health: 0},
+		},
+		Round:  30,
+		Hits:   25,
+		Stings: 12,
+	}
+
+	result := createGameResult(state)
+
+	if !result.Won || !result.QueenDied || result.Rounds != 30 || result.Hits != 25 || result.Stings != 12 || result.PlayerHealth != 40 || result.Seed != 9 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	if len(result.Survivors) != 0 {
+		t.Errorf("Expected no survivors. Received: %v", result.Survivors)
+	}
+
+	state.Player.Health = -5
+	state.Hive[0].Health = 100
+	state.Hive[1].Health = 30
+
+	result = createGameResult(state)
+
+	if result.Won || result.QueenDied || result.Survivors[WorkerBee] != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+// TestSummarize ensures win rates and distributions are calculated correctly.
+func TestSummarize(t *testing.T) {
+	results := []GameResult{}
+	for rounds := uint(1); rounds <= 100; rounds++ {
+		results = append(results, GameResult{Won: rounds%4 == 0, Rounds: rounds, Hits: 2, Stings: rounds % 10})
+	}
+
+	report := Summarize(results)
+
+	if report.Games != 100 || report.Wins != 25 || report.WinRate != 0.25 {
+		t.Errorf("Unexpected win rate: %+v", report)
+	}
+
+	expected := Distribution{Mean: 50.5, P50: 50, P90: 90, P99: 99, Max: 100}
+	if report.Rounds != expected {
+		t.Errorf("Expected rounds %+v. Received: %+v", expected, report.Rounds)
+	}
+
+	if report.Hits != (Distribution{Mean: 2, P50: 2, P90: 2, P99: 2, Max: 2}) {
+		t.Errorf("Unexpected hits: %+v", report.Hits)
+	}
+
+	if report.Stings.Max != 9 || report.Stings.Mean != 4.5 {
+		t.Errorf("Unexpected stings: %+v", report.Stings)
+	}
+
+	if empty := Summarize(nil); empty != (SimulationReport{}) {
+		t.Errorf("Expected an empty report. Received: %+v", empty)
+	}
+}
@@ -0,0 +1,806 @@
+package game
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Solution is the exact outcome of a game played from a given state, assuming the player
+// attacks a random bee every round and never uses an item, that no status effects come
+// into play and that every attack deals fixed damage without critical hits.
+type Solution struct {
+	WinProbability float64
+	ExpectedRounds float64 // Rounds left to play, counting the one about to start.
+	States         int     // Number of distinct game states the solver worked through.
+}
+
+// solverTolerance is how little the values of hives that lead back to each other through
+// the queen's eggs may change in a sweep before they are taken as solved.
+const solverTolerance = 1e-14
+
+// solverSweeps is the most sweeps the solver makes over hives that lead back to each
+// other before it gives up.
+const solverSweeps = 1_000_000
+
+// beeGroup is every bee of the same type, miss chance and health. Bees in the same group
+// are interchangeable, so a hive can be described by how many bees are in each group.
+type beeGroup struct {
+	beeType    BeeType
+	missChance uint
+	health     int
+	next       int     // Index of the group a bee moves to once hit, or -1 if the hit kills it.
+	damage     int     // Hit points the player loses when a bee in this group stings.
+	miss       float64 // Chance a sting from a bee in this group misses.
+}
+
+// hiveCounts is how many bees are in each group.
+type hiveCounts []int
+
+// key encodes the counts so they can be looked up in a map.
+func (counts hiveCounts) key() string {
+	buffer := make([]byte, 0, len(counts)*2)
+	for _, count := range counts {
+		buffer = binary.AppendUvarint(buffer, uint64(count))
+	}
+
+	return string(buffer)
+}
+
+// total returns the number of bees in the hive.
+func (counts hiveCounts) total() int {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	return total
+}
+
+// solverLayer holds every hive that is the same number of hits on bees other than drones
+// away from the start. Hitting a drone or hatching new ones keeps the hive in its layer.
+type solverLayer struct {
+	hives   []hiveCounts
+	indexes map[string]int
+}
+
+// createSolverLayer returns an empty layer.
+func createSolverLayer() solverLayer {
+	return solverLayer{indexes: map[string]int{}}
+}
+
+// add adds the hive to the layer unless it's already there, and returns its index.
+func (layer *solverLayer) add(counts hiveCounts) int {
+	key := counts.key()
+	if index, ok := layer.indexes[key]; ok {
+		return index
+	}
+
+	layer.indexes[key] = len(layer.hives)
+	layer.hives = append(layer.hives, counts)
+
+	return len(layer.hives) - 1
+}
+
+// turnValues holds the chance of winning and the expected rounds left for every health
+// the player might have, indexed by health.
+type turnValues struct {
+	wins   []float64
+	rounds []float64
+}
+
+// createTurnValues makes room for every health from 1 to health.
+func createTurnValues(health int) turnValues {
+	return turnValues{
+		wins:   make([]float64, health+1),
+		rounds: make([]float64, health+1),
+	}
+}
+
+// hiveValues holds the values of a hive at the start of the player's turn and at the start
+// of the hive's turn, in every phase of the queen's laying.
+type hiveValues struct {
+	player []turnValues // Indexed by phase.
+	hive   []turnValues // Indexed by phase.
+}
+
+// createHiveValues makes room for every phase and every health from 1 to health.
+func createHiveValues(phases, health int) hiveValues {
+	values := hiveValues{
+		player: make([]turnValues, phases),
+		hive:   make([]turnValues, phases),
+	}
+
+	for phase := range phases {
+		values.player[phase] = createTurnValues(health)
+		values.hive[phase] = createTurnValues(health)
+	}
+
+	return values
+}
+
+// solverMove is one way the player's attack or the hive's sting can play out.
+type solverMove struct {
+	chance float64
+	damage int         // Hit points the player loses to a sting.
+	values *hiveValues // The hive after the player's hit, or nil if the hit wins the game.
+}
+
+// solverHive is a hive being solved along with every move that can be made from it.
+type solverHive struct {
+	values *hiveValues
+	starts []*hiveValues // The hive each phase's round starts with once any eggs have hatched, indexed by phase.
+	hits   []solverMove  // Every hit that lands.
+	stings []solverMove  // Every sting that hurts.
+	miss   float64       // Chance the hive's turn leaves the player's health as it was.
+	drones int           // Hit points the hive's drones have left, which hits lower and hatching raises.
+}
+
+// Solve computes the exact chance that the player wins a game played by the rules in
+// config from the given state, along with the number of rounds it is expected to last.
+// A state in round 0 is solved from the start of the first round, and any other from the
+// player's turn in its round, as the game is saved.
+//
+// The game is a Markov chain: every round only depends on the player's health, how many
+// bees of each type are left at each health and, while the queen lays eggs, how many
+// rounds are left until the next clutch. Hits on bees other than drones bring the hive
+// one step closer to being wiped out, so the solver works backwards from the hives that
+// are closest to dying, one layer at a time, keeping only the values of the layer after
+// the one being solved. Within a layer, hives that lead back to each other by hitting
+// drones and hatching new ones are solved together, one health at a time, by sweeping
+// over them until their values settle.
+func Solve(config GameConfig, state GameState) (Solution, error) {
+	if err := config.Validate(); err != nil {
+		return Solution{}, err
+	}
+
+	if state.Player.Health <= 0 || len(state.Hive) == 0 {
+		return Solution{}, errors.New("the game is already over")
+	}
+
+	if hasStatusEffects(config, state) {
+		return Solution{}, errors.New("the solver can't account for status effects")
+	}
+
+	if state.Player.Immune {
+		return Solution{}, errors.New("the solver can't account for the player's immunity to the next sting")
+	}
+
+	if hasRandomDamage(config) {
+		return Solution{}, errors.New("the solver can't account for critical hits or damage spreads")
+	}
+
+	if config.hasAbilities() || state.Commanded {
+		return Solution{}, errors.New("the solver can't account for bee abilities")
+	}
+
+	groups, start, spawn, err := createBeeGroups(config, state.Hive)
+	if err != nil {
+		return Solution{}, err
+	}
+
+	solver := &solver{
+		groups:     groups,
+		health:     state.Player.Health,
+		playerMiss: missProbability(state.Player.MissChance),
+		phases:     1,
+		spawn:      spawn,
+		spawnCount: int(config.SpawnCount),
+		spawnCap:   int(config.SpawnCap),
+	}
+
+	if spawn >= 0 {
+		solver.phases = int(config.SpawnInterval)
+	}
+
+	if solver.playerMiss == 1 && solver.hiveMiss(start) == 1 {
+		return Solution{}, errors.New("the game never ends, as neither side can hurt the other")
+	}
+
+	// A new game starts with the first round, whose eggs may hatch before the player's
+	// turn. A saved game is resumed on the player's turn, after any have hatched.
+	round := max(state.Round, 1)
+	phase := int(round % uint(solver.phases))
+	if state.Round == 0 {
+		start = solver.hatch(start, phase)
+	}
+
+	layers := solver.layers(start)
+	solution := Solution{}
+
+	// The values of every hive in the layer after the one being solved.
+	var next []hiveValues
+
+	for depth := len(layers) - 1; depth >= 0; depth-- {
+		solved, err := solver.solveLayer(layers, depth, next)
+		if err != nil {
+			return Solution{}, err
+		}
+
+		if depth == 0 {
+			player := solved[0].player[phase]
+			solution.WinProbability = player.wins[solver.health]
+			solution.ExpectedRounds = player.rounds[solver.health]
+		}
+
+		// Only the hive's turn is looked up from the layer before.
+		for index := range solved {
+			solved[index].player = nil
+		}
+
+		solution.States += len(layers[depth].hives) * solver.health * solver.phases
+		next = solved
+	}
+
+	return solution, nil
+}
+
+// hasStatusEffects reports whether status effects could come into play in a game played
+// by the config from the given state: either someone is already under one, or hits or
+// stings can inflict them.
+func hasStatusEffects(config GameConfig, state GameState) bool {
+	if state.Player.Effects != (Effects{}) || config.PlayerEffectChance > 0 {
+		return true
+	}
+
+	for _, bee := range state.Hive {
+		if bee.Effects != (Effects{}) {
+			return true
+		}
+	}
+
+	for _, stats := range config.Bees {
+		if stats.EffectChance > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasRandomDamage reports whether any attack in a game played by the config can be a
+// critical hit or have its damage spread.
+func hasRandomDamage(config GameConfig) bool {
+	if config.PlayerCritChance > 0 || config.PlayerDamageSpread > 0 {
+		return true
+	}
+
+	for _, stats := range config.Bees {
+		if stats.CritChance > 0 || stats.DamageSpread > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createBeeGroups sorts the bees into groups, along with every group they could end up in
+// as they're hit, and returns how many bees start in each group. If the queen lays eggs,
+// the group the drones hatch into is returned as well, or -1 if she doesn't.
+func createBeeGroups(config GameConfig, hive []Bee) ([]beeGroup, hiveCounts, int, error) {
+	var groups []beeGroup
+
+	bees := slices.Clone(hive)
+
+	drone, spawning := config.Bees[DroneBee]
+	spawning = spawning && config.SpawnInterval > 0
+	if spawning {
+		bees = append(bees, Bee{Type: DroneBee, Health: drone.Health, MissChance: drone.MissChance})
+	}
+
+	for _, bee := range bees {
+		stats, ok := config.Bees[bee.Type]
+		if !ok {
+			return nil, nil, -1, fmt.Errorf("the rules don't describe the %s", bee.Type)
+		}
+
+		if bee.Health <= 0 {
+			return nil, nil, -1, errors.New("the game is already over")
+		}
+
+		for health := bee.Health; health > 0; health -= stats.DamageTaken {
+			groups = append(groups, beeGroup{
+				beeType:    bee.Type,
+				missChance: bee.MissChance,
+				health:     health,
+				damage:     stats.DamageDealt,
+				miss:       missProbability(bee.MissChance),
+			})
+		}
+	}
+
+	compareGroups := func(a, b beeGroup) int {
+		return cmp.Or(cmp.Compare(a.beeType, b.beeType), cmp.Compare(a.missChance, b.missChance), cmp.Compare(a.health, b.health))
+	}
+
+	slices.SortFunc(groups, compareGroups)
+	groups = slices.CompactFunc(groups, func(a, b beeGroup) bool { return compareGroups(a, b) == 0 })
+
+	find := func(beeType BeeType, missChance uint, health int) int {
+		index, found := slices.BinarySearchFunc(groups, beeGroup{beeType: beeType, missChance: missChance, health: health}, compareGroups)
+		if !found {
+			return -1
+		}
+
+		return index
+	}
+
+	for index := range groups {
+		group := &groups[index]
+		group.next = find(group.beeType, group.missChance, group.health-config.Bees[group.beeType].DamageTaken)
+	}
+
+	start := make(hiveCounts, len(groups))
+	for _, bee := range hive {
+		start[find(bee.Type, bee.MissChance, bee.Health)]++
+	}
+
+	spawn := -1
+	if spawning {
+		spawn = find(DroneBee, drone.MissChance, drone.Health)
+	}
+
+	return groups, start, spawn, nil
+}
+
+// solver holds what stays the same across every state of the game being solved.
+type solver struct {
+	groups     []beeGroup
+	health     int
+	playerMiss float64
+	phases     int // Rounds from one clutch of the queen's eggs to the next, or 1 if she lays none.
+	spawn      int // Index of the group drones hatch into, or -1 if the queen lays no eggs.
+	spawnCount int
+	spawnCap   int
+}
+
+// hiveMiss returns the chance the bee picked from the hive leaves the player's health as
+// it was: either its sting misses or, for bees that deal no damage, it lands harmlessly.
+func (solver *solver) hiveMiss(counts hiveCounts) float64 {
+	total := float64(counts.total())
+	miss := 0.0
+
+	for group, count := range counts {
+		if solver.groups[group].damage <= 0 {
+			miss += float64(count) / total
+			continue
+		}
+
+		miss += float64(count) / total * solver.groups[group].miss
+	}
+
+	return miss
+}
+
+// hit returns the hive after a bee in the group was hit, or false if the hit won the game.
+func (solver *solver) hit(counts hiveCounts, group int) (hiveCounts, bool) {
+	after := slices.Clone(counts)
+	after[group]--
+
+	if next := solver.groups[group].next; next >= 0 {
+		after[next]++
+		return after, true
+	}
+
+	// A dead bee is removed from the hive, unless it was the last queen, in which case
+	// the rest of the hive dies with her.
+	if solver.groups[group].beeType == QueenBee && !solver.hasQueen(after) {
+		return nil, false
+	}
+
+	return after, after.total() > 0
+}
+
+// hatch returns the hive at the start of a round in the given phase, once any of the
+// queen's eggs have hatched. They only hatch in phase 0, while she is alive and the hive
+// holds fewer drones than the cap.
+func (solver *solver) hatch(counts hiveCounts, phase int) hiveCounts {
+	if solver.spawn < 0 || phase != 0 || !solver.hasQueen(counts) {
+		return counts
+	}
+
+	living := 0
+	for group, count := range counts {
+		if solver.groups[group].beeType == DroneBee {
+			living += count
+		}
+	}
+
+	if living >= solver.spawnCap {
+		return counts
+	}
+
+	after := slices.Clone(counts)
+	after[solver.spawn] += min(solver.spawnCount, solver.spawnCap-living)
+
+	return after
+}
+
+// hasQueen reports whether the hive has a queen left.
+func (solver *solver) hasQueen(counts hiveCounts) bool {
+	for group, count := range counts {
+		if count > 0 && solver.groups[group].beeType == QueenBee {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canHit reports whether the player's attack can ever land.
+func (solver *solver) canHit() bool {
+	return solver.playerMiss < 1
+}
+
+// layers returns every hive the game can reach from the starting hive, grouped by the
+// number of hits on bees other than drones it takes to get there.
+func (solver *solver) layers(start hiveCounts) []solverLayer {
+	layer := createSolverLayer()
+	layer.add(start)
+
+	layers := []solverLayer{}
+
+	for len(layer.hives) > 0 {
+		next := createSolverLayer()
+
+		// Hitting drones and hatching new ones keeps the hive in the layer, so the layer
+		// grows while it's walked.
+		for index := 0; index < len(layer.hives); index++ {
+			counts := layer.hives[index]
+
+			for group, count := range counts {
+				if count == 0 || !solver.canHit() {
+					continue
+				}
+
+				after, ok := solver.hit(counts, group)
+				if !ok {
+					continue
+				}
+
+				if solver.groups[group].beeType == DroneBee {
+					layer.add(after)
+				} else {
+					next.add(after)
+				}
+			}
+
+			layer.add(solver.hatch(counts, 0))
+		}
+
+		layers = append(layers, layer)
+		layer = next
+	}
+
+	return layers
+}
+
+// solveLayer works out the values of every hive in the layer at the given depth. The
+// values of the layer after it must already be solved in next.
+//
+// Hives are solved after every hive they can lead to in the layer, and those that lead
+// back to each other are solved together.
+func (solver *solver) solveLayer(layers []solverLayer, depth int, next []hiveValues) ([]hiveValues, error) {
+	layer := layers[depth]
+	solved := make([]hiveValues, len(layer.hives))
+
+	for index := range solved {
+		solved[index] = createHiveValues(solver.phases, solver.health)
+	}
+
+	for _, component := range solver.components(layer) {
+		hives := make([]*solverHive, len(component))
+		for index, hive := range component {
+			hives[index] = solver.createSolverHive(layers, depth, hive, solved, next)
+		}
+
+		var err error
+		if len(hives) == 1 {
+			err = solver.solveHive(hives[0])
+		} else {
+			err = solver.solveComponent(hives)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return solved, nil
+}
+
+// components splits the layer into the groups of hives that lead back to each other,
+// ordered so that every group comes after the groups it can lead to. It is Tarjan's
+// algorithm, following the hits on drones and the eggs hatching that keep the hive in
+// the layer.
+func (solver *solver) components(layer solverLayer) [][]int {
+	successors := func(hive int) []int {
+		counts := layer.hives[hive]
+		indexes := []int{}
+
+		for group, count := range counts {
+			if count == 0 || !solver.canHit() || solver.groups[group].beeType != DroneBee {
+				continue
+			}
+
+			if after, ok := solver.hit(counts, group); ok {
+				indexes = append(indexes, layer.indexes[after.key()])
+			}
+		}
+
+		if hatched := solver.hatch(counts, 0); !slices.Equal(hatched, counts) {
+			indexes = append(indexes, layer.indexes[hatched.key()])
+		}
+
+		return indexes
+	}
+
+	order := make([]int, len(layer.hives))
+	lowest := make([]int, len(layer.hives))
+	onStack := make([]bool, len(layer.hives))
+	stack := []int{}
+	components := [][]int{}
+	visited := 0
+
+	var visit func(hive int)
+	visit = func(hive int) {
+		visited++
+		order[hive], lowest[hive] = visited, visited
+		stack = append(stack, hive)
+		onStack[hive] = true
+
+		for _, successor := range successors(hive) {
+			switch {
+			case order[successor] == 0:
+				visit(successor)
+				lowest[hive] = min(lowest[hive], lowest[successor])
+			case onStack[successor]:
+				lowest[hive] = min(lowest[hive], order[successor])
+			}
+		}
+
+		if lowest[hive] != order[hive] {
+			return
+		}
+
+		component := []int{}
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+
+			if last == hive {
+				break
+			}
+		}
+
+		components = append(components, component)
+	}
+
+	for hive := range layer.hives {
+		if order[hive] == 0 {
+			visit(hive)
+		}
+	}
+
+	return components
+}
+
+// createSolverHive gathers every move that can be made from the hive at the index in the
+// layer at the given depth. The hives it leads to have their values in solved, if they're
+// in the same layer, or in next.
+func (solver *solver) createSolverHive(layers []solverLayer, depth, index int, solved, next []hiveValues) *solverHive {
+	counts := layers[depth].hives[index]
+	total := float64(counts.total())
+
+	hive := &solverHive{
+		values: &solved[index],
+		starts: make([]*hiveValues, solver.phases),
+		miss:   solver.hiveMiss(counts),
+	}
+
+	for phase := range solver.phases {
+		hive.starts[phase] = &solved[layers[depth].indexes[solver.hatch(counts, phase).key()]]
+	}
+
+	for group, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		picked := float64(count) / total
+
+		if solver.groups[group].beeType == DroneBee {
+			hive.drones += count * solver.groups[group].health
+		}
+
+		if solver.canHit() {
+			move := solverMove{chance: picked * (1 - solver.playerMiss)}
+
+			if after, ok := solver.hit(counts, group); ok {
+				if solver.groups[group].beeType == DroneBee {
+					move.values = &solved[layers[depth].indexes[after.key()]]
+				} else {
+					move.values = &next[layers[depth+1].indexes[after.key()]]
+				}
+			}
+
+			hive.hits = append(hive.hits, move)
+		}
+
+		// Stings that do no damage count as misses, so the player's health only goes
+		// down here and the values they lead to have already been solved.
+		if damage := solver.groups[group].damage; damage > 0 && solver.groups[group].miss < 1 {
+			hive.stings = append(hive.stings, solverMove{chance: picked * (1 - solver.groups[group].miss), damage: damage})
+		}
+	}
+
+	return hive
+}
+
+// start returns the values at the start of the player's turn in a round of the given
+// phase, once any of the queen's eggs have hatched.
+func (hive *solverHive) start(phase, health int) (float64, float64) {
+	phase %= len(hive.starts)
+	values := hive.starts[phase].player[phase]
+
+	return values.wins[health], values.rounds[health]
+}
+
+// strike returns what the player's hits in a round of the given phase are worth.
+func (hive *solverHive) strike(phase, health int) (float64, float64) {
+	var wins, rounds float64
+
+	for _, move := range hive.hits {
+		if move.values == nil {
+			wins += move.chance
+			continue
+		}
+
+		wins += move.chance * move.values.hive[phase].wins[health]
+		rounds += move.chance * move.values.hive[phase].rounds[health]
+	}
+
+	return wins, rounds
+}
+
+// sting returns what the hive's stings that hurt in a round of the given phase are worth.
+// They lower the player's health, so the values they lead to have already been solved.
+func (hive *solverHive) sting(phase, health int) (float64, float64) {
+	var wins, rounds float64
+
+	for _, move := range hive.stings {
+		if left := health - move.damage; left > 0 {
+			leftWins, leftRounds := hive.start(phase+1, left)
+			wins += move.chance * leftWins
+			rounds += move.chance * leftRounds
+		}
+	}
+
+	return wins, rounds
+}
+
+// solveHive works out the values at the start of the player's turn and at the start of
+// the hive's turn for a hive that no other hive in its layer leads back to, in every
+// phase and for every health the player might have.
+//
+// When both sides miss, or the hive's sting does no damage, the game moves on to the
+// next round with the same hive, so every phase depends on the one after it:
+//
+//	player[phase] = hit + playerMiss * hive[phase]
+//	hive[phase]   = sting + hiveMiss * player[phase+1]
+//
+// After the last phase comes phase 0 of the hive the queen's eggs hatch into. If none
+// hatch, the phases go round in a circle, which is solved for player[0] directly. Every
+// round adds one to the expected rounds.
+func (solver *solver) solveHive(hive *solverHive) error {
+	phases := solver.phases
+	repeat := solver.playerMiss * hive.miss
+
+	// What each phase's round is worth on its own, leaving out the rounds after it.
+	wins := make([]float64, phases)
+	rounds := make([]float64, phases)
+
+	for health := 1; health <= solver.health; health++ {
+		for phase := range phases {
+			hitWins, hitRounds := hive.strike(phase, health)
+			stingWins, stingRounds := hive.sting(phase, health)
+
+			wins[phase] = hitWins + solver.playerMiss*stingWins
+			rounds[phase] = 1 + hitRounds + solver.playerMiss*stingRounds
+		}
+
+		lowest := 0
+		if hive.starts[0] == hive.values {
+			var circleWins, circleRounds float64
+
+			for phase := phases - 1; phase >= 0; phase-- {
+				circleWins = wins[phase] + repeat*circleWins
+				circleRounds = rounds[phase] + repeat*circleRounds
+			}
+
+			circle := 1 - math.Pow(repeat, float64(phases))
+			hive.values.player[0].wins[health] = circleWins / circle
+			hive.values.player[0].rounds[health] = circleRounds / circle
+			lowest = 1
+		}
+
+		for phase := phases - 1; phase >= lowest; phase-- {
+			nextWins, nextRounds := hive.start(phase+1, health)
+			hive.values.player[phase].wins[health] = wins[phase] + repeat*nextWins
+			hive.values.player[phase].rounds[health] = rounds[phase] + repeat*nextRounds
+		}
+
+		for phase := range phases {
+			stingWins, stingRounds := hive.sting(phase, health)
+			nextWins, nextRounds := hive.start(phase+1, health)
+			hive.values.hive[phase].wins[health] = stingWins + hive.miss*nextWins
+			hive.values.hive[phase].rounds[health] = stingRounds + hive.miss*nextRounds
+		}
+
+		if rounds := hive.values.player[0].rounds[health]; math.IsNaN(rounds) || math.IsInf(rounds, 0) {
+			return errors.New("the game never ends, as neither side can hurt the other")
+		}
+	}
+
+	return nil
+}
+
+// solveComponent works out the values of hives that lead back to each other, by hitting
+// drones until the queen lays more. They can't be solved one after the other, so the
+// solver sweeps over them, one health at a time, until their values settle. Every sweep
+// goes through the phases backwards and the hives from the fewest hit points of drones
+// left to the most, so that all but the values the eggs hatch into are up to date.
+func (solver *solver) solveComponent(hives []*solverHive) error {
+	phases := solver.phases
+
+	slices.SortFunc(hives, func(a, b *solverHive) int { return cmp.Compare(a.drones, b.drones) })
+
+	for health := 1; health <= solver.health; health++ {
+		// Start from the values for one less health, which are usually close.
+		if health > 1 {
+			for _, hive := range hives {
+				for phase := range phases {
+					hive.values.player[phase].wins[health] = hive.values.player[phase].wins[health-1]
+					hive.values.player[phase].rounds[health] = hive.values.player[phase].rounds[health-1]
+				}
+			}
+		}
+
+		for sweep := 0; ; sweep++ {
+			if sweep == solverSweeps {
+				return errors.New("the solver couldn't settle on the odds of this game")
+			}
+
+			change := 0.0
+
+			for phase := phases - 1; phase >= 0; phase-- {
+				for _, hive := range hives {
+					player := &hive.values.player[phase]
+					before := player.rounds[health]
+
+					stingWins, stingRounds := hive.sting(phase, health)
+					nextWins, nextRounds := hive.start(phase+1, health)
+					hive.values.hive[phase].wins[health] = stingWins + hive.miss*nextWins
+					hive.values.hive[phase].rounds[health] = stingRounds + hive.miss*nextRounds
+
+					hitWins, hitRounds := hive.strike(phase, health)
+					player.wins[health] = hitWins + solver.playerMiss*hive.values.hive[phase].wins[health]
+					player.rounds[health] = 1 + hitRounds + solver.playerMiss*hive.values.hive[phase].rounds[health]
+
+					change = max(change, math.Abs(player.rounds[health]-before)/player.rounds[health])
+				}
+			}
+
+			if change <= solverTolerance {
+				break
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package game
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+// soloConfig returns rules for a hive of the given bees and a player with the given health and miss chance.
+func soloConfig(playerHealth int, playerMiss uint, bees map[BeeType]BeeStats) GameConfig {
+	return GameConfig{PlayerHealth: playerHealth, PlayerMissChance: playerMiss, Bees: bees}
+}
+
+// TestSolveExact checks the solver against games small enough to work out by hand.
+func TestSolveExact(t *testing.T) {
+	drone := BeeStats{Health: 30, DamageTaken: 30, DamageDealt: 10, MissChance: 0, Count: 1}
+
+	halfMissDrone := drone
+	halfMissDrone.MissChance = 50
+
+	// A harmless queen who dies in one hit, guarded by a harmless drone she replaces.
+	layingHive := map[BeeType]BeeStats{
+		QueenBee: {Health: 10, DamageTaken: 10, MissChance: 100, Count: 1},
+		DroneBee: {Health: 10, DamageTaken: 10, MissChance: 100, Count: 1},
+	}
+
+	everyRound := soloConfig(1, 0, layingHive)
+	everyRound.SpawnInterval, everyRound.SpawnCount, everyRound.SpawnCap = 1, 1, 1
+
+	everyOtherRound := everyRound
+	everyOtherRound.SpawnInterval = 2
+
+	scenarios := []struct {
+		name   string
+		config GameConfig
+		wins   float64
+		rounds float64
+	}{
+		// The player kills the only bee with their first attack.
+		{"certain win", soloConfig(10, 0, map[BeeType]BeeStats{DroneBee: drone}), 1, 1},
+		// The player either kills the bee or is killed by it in the first round.
+		{"coin toss", soloConfig(10, 50, map[BeeType]BeeStats{DroneBee: drone}), 0.5, 1},
+		// win = 1/2 + 1/4 win and rounds = 1 + 1/4 rounds, as both missing repeats the round.
+		{"repeated rounds", soloConfig(10, 50, map[BeeType]BeeStats{DroneBee: halfMissDrone}), 2.0 / 3, 4.0 / 3},
+		// Half the time the queen is hit, and otherwise the drone, which hatches again at
+		// the start of the next round, so rounds = 1 + 1/2 rounds.
+		{"drone hatching every round", everyRound, 1, 2},
+		// Killing the drone in round 1 leaves the queen alone in round 3 at the latest.
+		{"drone hatching every other round", everyOtherRound, 1, 1.0/2 + 2.0/4 + 3.0/4},
+	}
+
+	for _, scenario := range scenarios {
+		solution, err := Solve(scenario.config, NewGameState(scenario.config, 1))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", scenario.name, err)
+		}
+
+		if math.Abs(solution.WinProbability-scenario.wins) > 1e-12 || math.Abs(solution.ExpectedRounds-scenario.rounds) > 1e-12 {
+			t.Errorf("%s: expected %.4f wins over %.4f rounds. Received: %+v", scenario.name, scenario.wins, scenario.rounds, solution)
+		}
+	}
+}
+
+// TestSolveMidGame ensures a game can be solved from any state, including bees that have
+// already been hit.
+func TestSolveMidGame(t *testing.T) {
+	config := withoutSpawning(DefaultGameConfig())
+
+	// The bees never sting and the player never misses, so the game lasts exactly as many
+	// rounds as it takes to kill every bee: 2 for the first drone, 1 for the second and 3
+	// for the worker.
+	state := GameState{
+		Player: Player{Health: 1, MissChance: 0},
+		Hive: []Bee{
+			{Type: DroneBee, Health: 60, MissChance: 100},
+			{Type: DroneBee, Health: 30, MissChance: 100},
+			{Type: WorkerBee, Health: 75, MissChance: 100},
+		},
+	}
+
+	solution, err := Solve(config, state)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if solution.WinProbability != 1 || math.Abs(solution.ExpectedRounds-6) > 1e-12 {
+		t.Errorf("Expected a certain win after 6 rounds. Received: %+v", solution)
+	}
+
+	// Killing the queen ends the game, so with a queen that dies in one hit alongside two
+	// drones the game lasts 3 rounds if she is hit last, and fewer otherwise.
+	state.Hive = []Bee{
+		{Type: QueenBee, Health: 10, MissChance: 100},
+		{Type: DroneBee, Health: 30, MissChance: 100},
+		{Type: DroneBee, Health: 30, MissChance: 100},
+	}
+
+	solution, err = Solve(config, state)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// 1/3 of the time she's hit in round 1, 2/3 * 1/2 in round 2 and the rest in round 3.
+	if expected := 1.0/3 + 2*(1.0/3) + 3*(1.0/3); math.Abs(solution.ExpectedRounds-expected) > 1e-12 {
+		t.Errorf("Expected %.4f rounds. Received: %+v", expected, solution)
+	}
+
+	// A game is saved on the player's turn, after the round's eggs have hatched. With a
+	// drone hatching every other round, a game saved in round 2 ends in it half the time
+	// and otherwise with the queen alone in round 3.
+	config.SpawnInterval, config.SpawnCount, config.SpawnCap = 2, 1, 1
+	state.Round = 2
+	state.Hive = []Bee{{Type: QueenBee, Health: 10, MissChance: 100}, {Type: DroneBee, Health: 30, MissChance: 100}}
+
+	solution, err = Solve(config, state)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if expected := 1.0/2 + 2*(1.0/2); math.Abs(solution.ExpectedRounds-expected) > 1e-12 {
+		t.Errorf("Expected %.4f rounds. Received: %+v", expected, solution)
+	}
+}
+
+// TestSolveMatchesSimulation ensures the solver agrees with the engine on hives small
+// enough to simulate thousands of games in a test, including one whose drones sting for
+// no damage at all and one whose queen lays eggs.
+func TestSolveMatchesSimulation(t *testing.T) {
+	config := soloConfig(40, 20, map[BeeType]BeeStats{
+		QueenBee:  {Health: 40, DamageTaken: 20, DamageDealt: 8, MissChance: 10, Count: 1},
+		WorkerBee: {Health: 50, DamageTaken: 25, DamageDealt: 4, MissChance: 30, Count: 3},
+		DroneBee:  {Health: 30, DamageTaken: 30, DamageDealt: 2, MissChance: 40, Count: 5},
+	})
+
+	harmless := withSetting(config, "drone.damage_dealt", 0)
+
+	for name, config := range map[string]GameConfig{"standard": config, "harmless drones": harmless, "laying queen": withSpawning(config)} {
+		solution, err := Solve(config, NewGameState(config, 1))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+
+		const games = 20000
+
+		results, err := Simulate(context.Background(), config, games, 4, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		report := Summarize(results)
+
+		// Allow for five standard errors of the simulated win rate.
+		tolerance := 5 * math.Sqrt(solution.WinProbability*(1-solution.WinProbability)/games)
+		if math.Abs(report.WinRate-solution.WinProbability) > tolerance {
+			t.Errorf("%s: expected a win rate of %.4f. Simulated: %.4f", name, solution.WinProbability, report.WinRate)
+		}
+
+		if math.Abs(report.Rounds.Mean-solution.ExpectedRounds) > 0.2 {
+			t.Errorf("%s: expected %.2f rounds. Simulated: %.2f", name, solution.ExpectedRounds, report.Rounds.Mean)
+		}
+	}
+}
+
+// TestSolveErrors ensures games that can't be solved are reported.
+func TestSolveErrors(t *testing.T) {
+	config := DefaultGameConfig()
+
+	scenarios := []struct {
+		config      GameConfig
+		state       GameState
+		expectedErr string
+	}{
+		{GameConfig{}, NewGameState(config, 1), "player.health must be > 0"},
+		{config, GameState{Player: Player{Health: 0}, Hive: []Bee{{Type: DroneBee, Health: 10}}}, "the game is already over"},
+		{config, GameState{Player: Player{Health: 10}}, "the game is already over"},
+		{config, GameState{Player: Player{Health: 10}, Hive: []Bee{{Type: DroneBee, Health: 0}}}, "the game is already over"},
+		{config, GameState{Player: Player{Health: 10}, Hive: []Bee{{Type: BeeType(42), Health: 10}}}, "the rules don't describe"},
+		{config, GameState{Player: Player{Health: 10, MissChance: 100}, Hive: []Bee{{Type: DroneBee, Health: 10, MissChance: 100}}}, "the game never ends"},
+		{config, GameState{Player: Player{Health: 10, Effects: Effects{Poisoned: 2}}, Hive: []Bee{{Type: DroneBee, Health: 10}}}, "status effects"},
+		{config, GameState{Player: Player{Health: 10}, Hive: []Bee{{Type: DroneBee, Health: 10, Effects: Effects{Smoked: 1}}}}, "status effects"},
+		{withEffectChance(config), NewGameState(config, 1), "status effects"},
+		{config, GameState{Player: Player{Health: 10, Immune: true}, Hive: []Bee{{Type: DroneBee, Health: 10}}}, "immunity"},
+		{withSetting(config, "player.effect_chance", 10), NewGameState(config, 1), "status effects"},
+		{withSetting(config, "player.crit_chance", 10), NewGameState(config, 1), "critical hits"},
+		{withSetting(config, "worker.damage_spread", 20), NewGameState(config, 1), "damage spreads"},
+		{withSetting(config, "worker.ability_chance", 20), NewGameState(config, 1), "bee abilities"},
+		{config, GameState{Player: Player{Health: 10}, Hive: []Bee{{Type: DroneBee, Health: 10}}, Commanded: true}, "bee abilities"},
+	}
+
+	for _, scenario := range scenarios {
+		if _, err := Solve(scenario.config, scenario.state); err == nil || !strings.Contains(err.Error(), scenario.expectedErr) {
+			t.Errorf("Expected error to contain \"%s\". Received: %v", scenario.expectedErr, err)
+		}
+	}
+}
+
+// withEffectChance returns a copy of the config in which the drones' stings can inflict
+// their status effect.
+func withEffectChance(config GameConfig) GameConfig {
+	return withSetting(config, "drone.effect_chance", 50)
+}
+
+// withSpawning returns a copy of the config in which the queen lays eggs.
+func withSpawning(config GameConfig) GameConfig {
+	config.SpawnInterval, config.SpawnCount, config.SpawnCap = 5, 2, 30
+
+	return config
+}
+
+// withoutSpawning returns a copy of the config in which the queen never lays eggs.
+func withoutSpawning(config GameConfig) GameConfig {
+	config.SpawnInterval, config.SpawnCount, config.SpawnCap = 0, 0, 0
+
+	return config
+}
+
+// withSetting returns a copy of the config with one setting changed.
+func withSetting(config GameConfig, name string, value int) GameConfig {
+	config, _ = config.WithSetting(name, value)
+
+	return config
+}
@@ -0,0 +1,214 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxTuningPasses limits how many times Tune works through every parameter before it
+// settles for the closest ruleset it found.
+const maxTuningPasses = 5
+
+// TuningParameter is a ruleset setting Tune may change, along with the values it may take.
+type TuningParameter struct {
+	Setting string // Named the way it appears in ruleset files, e.g. "drone.health".
+	Min     int
+	Max     int
+}
+
+// TuningGoal is the win rate Tune aims for.
+type TuningGoal struct {
+	WinRate   float64 // The fraction of games the player should win, between 0 and 1.
+	Tolerance float64 // How far from WinRate the tuned win rate may be.
+}
+
+// reached reports whether the win rate is close enough to the goal.
+func (goal TuningGoal) reached(winRate float64) bool {
+	return math.Abs(winRate-goal.WinRate) <= goal.Tolerance
+}
+
+// closer reports whether winRate is closer to the goal than than.
+func (goal TuningGoal) closer(winRate, than float64) bool {
+	return math.Abs(winRate-goal.WinRate) < math.Abs(than-goal.WinRate)
+}
+
+// TuningResult is the ruleset Tune settled on.
+type TuningResult struct {
+	Config      GameConfig
+	WinRate     float64
+	Reached     bool // Whether the win rate is within the goal's tolerance.
+	Evaluations int  // Number of batches of games simulated along the way.
+}
+
+// tuner evaluates rulesets by simulating the same batch of games with each of them.
+type tuner struct {
+	ctx         context.Context
+	goal        TuningGoal
+	games       int
+	workers     int
+	seed        uint64
+	evaluations int
+}
+
+// Tune searches for a ruleset, starting from config, with which the player wins as often
+// as the goal asks for. Only the given parameters are changed, one at a time, bisecting
+// between their minimum and maximum values until the goal is reached or no parameter
+// brings the win rate any closer.
+//
+// Every ruleset is judged on the same batch of games simulated with the seeds seed,
+// seed+1, and so on, so that the differences between them are down to the rules alone.
+func Tune(ctx context.Context, config GameConfig, parameters []TuningParameter, goal TuningGoal, games, workers int, seed uint64) (TuningResult, error) {
+	if err := validateTuning(config, parameters, goal); err != nil {
+		return TuningResult{}, err
+	}
+
+	if games <= 0 {
+		return TuningResult{}, errors.New("the number of games must be > 0")
+	}
+
+	tuner := &tuner{ctx: ctx, goal: goal, games: games, workers: workers, seed: seed}
+
+	best, err := tuner.evaluate(config)
+	if err != nil {
+		return TuningResult{}, err
+	}
+
+	bestConfig := config
+
+	for range maxTuningPasses {
+		improved := false
+
+		for _, parameter := range parameters {
+			if goal.reached(best) {
+				break
+			}
+
+			tuned, winRate, err := tuner.bisect(bestConfig, parameter)
+			if err != nil {
+				return TuningResult{}, err
+			}
+
+			if goal.closer(winRate, best) {
+				bestConfig, best = tuned, winRate
+				improved = true
+			}
+		}
+
+		if goal.reached(best) || !improved {
+			break
+		}
+	}
+
+	return TuningResult{
+		Config:      bestConfig,
+		WinRate:     best,
+		Reached:     goal.reached(best),
+		Evaluations: tuner.evaluations,
+	}, nil
+}
+
+// validateTuning reports every parameter and goal Tune can't work with.
+func validateTuning(config GameConfig, parameters []TuningParameter, goal TuningGoal) error {
+	var errs []error
+
+	if len(parameters) == 0 {
+		errs = append(errs, errors.New("at least one parameter must be tuned"))
+	}
+
+	if goal.WinRate < 0 || goal.WinRate > 1 {
+		errs = append(errs, errors.New("the target win rate must be between 0 and 1"))
+	}
+
+	if goal.Tolerance < 0 {
+		errs = append(errs, errors.New("the tolerance must not be negative"))
+	}
+
+	for _, parameter := range parameters {
+		if parameter.Min > parameter.Max {
+			errs = append(errs, fmt.Errorf("%s: the minimum must not be above the maximum", parameter.Setting))
+			continue
+		}
+
+		for _, value := range []int{parameter.Min, parameter.Max} {
+			if _, err := config.WithSetting(parameter.Setting, value); err != nil {
+				errs = append(errs, fmt.Errorf("%s can't be %d: %w", parameter.Setting, value, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// evaluate returns the fraction of the batch of games the player wins with the config.
+func (tuner *tuner) evaluate(config GameConfig) (float64, error) {
+	tuner.evaluations++
+
+	results, err := Simulate(tuner.ctx, config, tuner.games, tuner.workers, tuner.seed)
+	if err != nil {
+		return 0, err
+	}
+
+	return Summarize(results).WinRate, nil
+}
+
+// evaluateSetting returns the config with the setting changed to value and its win rate.
+func (tuner *tuner) evaluateSetting(config GameConfig, setting string, value int) (GameConfig, float64, error) {
+	changed, err := config.WithSetting(setting, value)
+	if err != nil {
+		return GameConfig{}, 0, err
+	}
+
+	winRate, err := tuner.evaluate(changed)
+
+	return changed, winRate, err
+}
+
+// bisect searches the parameter's values for the one that brings the win rate closest to
+// the goal, assuming the win rate rises or falls steadily with the value. If the goal
+// lies outside the win rates of the minimum and maximum, the closer of the two is used.
+func (tuner *tuner) bisect(config GameConfig, parameter TuningParameter) (GameConfig, float64, error) {
+	low, lowRate, err := tuner.evaluateSetting(config, parameter.Setting, parameter.Min)
+	if err != nil {
+		return GameConfig{}, 0, err
+	}
+
+	high, highRate, err := tuner.evaluateSetting(config, parameter.Setting, parameter.Max)
+	if err != nil {
+		return GameConfig{}, 0, err
+	}
+
+	best, bestRate := low, lowRate
+	if tuner.goal.closer(highRate, bestRate) {
+		best, bestRate = high, highRate
+	}
+
+	// The goal can only be found between the two values if their win rates lie either side of it.
+	if (lowRate-tuner.goal.WinRate)*(highRate-tuner.goal.WinRate) > 0 {
+		return best, bestRate, nil
+	}
+
+	lowValue, highValue := parameter.Min, parameter.Max
+
+	for highValue-lowValue > 1 && !tuner.goal.reached(bestRate) {
+		middleValue := lowValue + (highValue-lowValue)/2
+
+		middle, middleRate, err := tuner.evaluateSetting(config, parameter.Setting, middleValue)
+		if err != nil {
+			return GameConfig{}, 0, err
+		}
+
+		if tuner.goal.closer(middleRate, bestRate) {
+			best, bestRate = middle, middleRate
+		}
+
+		if (lowRate-tuner.goal.WinRate)*(middleRate-tuner.goal.WinRate) <= 0 {
+			highValue = middleValue
+		} else {
+			lowValue, lowRate = middleValue, middleRate
+		}
+	}
+
+	return best, bestRate, nil
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestTune ensures the tuner finds a ruleset that hits a reachable win rate, and that
+// the ruleset plays out the way it reports.
+func TestTune(t *testing.T) {
+	config := DefaultGameConfig()
+	parameters := []TuningParameter{{Setting: "drone.health", Min: 10, Max: 120}, {Setting: "player.health", Min: 50, Max: 400}}
+	goal := TuningGoal{WinRate: 0.5, Tolerance: 0.05}
+
+	result, err := Tune(context.Background(), config, parameters, goal, 200, 4, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !result.Reached || !goal.reached(result.WinRate) {
+		t.Fatalf("Expected the goal to be reached. Result: %+v", result)
+	}
+
+	results, err := Simulate(context.Background(), result.Config, 200, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if winRate := Summarize(results).WinRate; winRate != result.WinRate {
+		t.Errorf("Expected the tuned ruleset to win %.3f of the games. Received: %.3f", result.WinRate, winRate)
+	}
+
+	if result.Config.Bees[WorkerBee] != config.Bees[WorkerBee] || result.Config.PlayerMissChance != config.PlayerMissChance {
+		t.Error("Expected settings that weren't tuned to stay the same.")
+	}
+}
+
+// TestTuneUnreachable ensures the closest ruleset is returned when the goal is out of reach.
+func TestTuneUnreachable(t *testing.T) {
+	parameters := []TuningParameter{{Setting: "drone.damage_dealt", Min: 0, Max: 3}}
+
+	result, err := Tune(context.Background(), DefaultGameConfig(), parameters, TuningGoal{WinRate: 1}, 100, 2, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if result.Reached || result.Config.Bees[DroneBee].DamageDealt != 0 {
+		t.Errorf("Expected harmless drones to be as close as the tuner gets. Result: %+v", result)
+	}
+}
+
+// TestTuneErrors ensures every problem with a tuning request is reported.
+func TestTuneErrors(t *testing.T) {
+	scenarios := []struct {
+		parameters  []TuningParameter
+		goal        TuningGoal
+		expectedErr string
+	}{
+		{nil, TuningGoal{WinRate: 0.5}, "at least one parameter must be tuned"},
+		{[]TuningParameter{{"drone.health", 10, 20}}, TuningGoal{WinRate: 1.5}, "the target win rate must be between 0 and 1"},
+		{[]TuningParameter{{"drone.health", 10, 20}}, TuningGoal{WinRate: 0.5, Tolerance: -1}, "the tolerance must not be negative"},
+		{[]TuningParameter{{"drone.health", 20, 10}}, TuningGoal{WinRate: 0.5}, "drone.health: the minimum must not be above the maximum"},
+		{[]TuningParameter{{"worker.count", 0, 5}}, TuningGoal{WinRate: 0.5}, "worker.count can't be 0"},
+		{[]TuningParameter{{"wasp.health", 1, 5}}, TuningGoal{WinRate: 0.5}, "unknown setting wasp.health"},
+	}
+
+	for _, scenario := range scenarios {
+		_, err := Tune(context.Background(), DefaultGameConfig(), scenario.parameters, scenario.goal, 10, 1, 1)
+		if err == nil || !strings.Contains(err.Error(), scenario.expectedErr) {
+			t.Errorf("Expected error to contain \"%s\". Received: %v", scenario.expectedErr, err)
+		}
+	}
+}